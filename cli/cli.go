@@ -0,0 +1,300 @@
+// Package cli is the spise command-line interface.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+// ErrUsage occurs when a command is invoked incorrectly.
+var ErrUsage = errors.New("invalid usage")
+
+type command struct {
+	args    string
+	summary string
+	run     func(s *session, args []string) error
+	// main is used instead of run for commands without a single game.
+	main func(args []string, stdout io.Writer, stderr io.Writer) int
+}
+
+var commands = map[string]command{
+	"new": {
+		"",
+		"set up a new game and show the invader deck",
+		runStatus,
+		nil,
+	},
+	"setup": {
+		"",
+		"show how to build the physical invader deck",
+		runSetup,
+		nil,
+	},
+	"explain": {
+		"",
+		"show each adversary rule applied to the invader deck",
+		runExplain,
+		nil,
+	},
+	"status": {
+		"",
+		"show the invader deck",
+		runStatus,
+		nil,
+	},
+	"draw": {
+		"[CARD...]",
+		"draw one or more invader cards (or deal one when --virtual)",
+		runDraw,
+		nil,
+	},
+	"return": {
+		"CARD",
+		"return a drawn card to the top of the deck (Fractured Days)",
+		runReturn,
+		nil,
+	},
+	"entrench": {
+		"CARD",
+		"add a Stage II or III card to the discard (Russia 5+)",
+		runEntrench,
+		nil,
+	},
+	"advance": {
+		"",
+		"advance the invader track at the end of the Invader Phase",
+		runAdvance,
+		nil,
+	},
+	"track": {
+		"",
+		"show what ravages, builds, and explores this turn",
+		runTrack,
+		nil,
+	},
+	"undo": {
+		"",
+		"undo the last change to the game",
+		runUndo,
+		nil,
+	},
+	"redo": {
+		"",
+		"redo the last undone change to the game",
+		runRedo,
+		nil,
+	},
+	"predict": {
+		"[STAGE]",
+		"predict the terrain of the next card (of the given stage)",
+		runPredict,
+		nil,
+	},
+	"chance": {
+		"TERRAIN [CARDS]",
+		"the exact chance of a terrain in the next cards (default 1)",
+		runChance,
+		nil,
+	},
+	"forecast": {
+		"[TURNS]",
+		"forecast the terrains of the next invader phases (default 3)",
+		runForecast,
+		nil,
+	},
+	"play": {
+		"",
+		"track a whole game in an interactive session",
+		runPlay,
+		nil,
+	},
+	"simulate": {
+		"[RUNS]",
+		"average how often each terrain ravages, builds, and explores",
+		runSimulate,
+		nil,
+	},
+	"difficulty": {
+		"MIN [MAX]",
+		"list the adversaries within a difficulty range",
+		nil,
+		difficulty,
+	},
+	"serve": {
+		"",
+		"host games behind a JSON http api",
+		nil,
+		serve,
+	},
+}
+
+// Run executes the spise command described by args.
+// It returns the exit code for the process.
+func Run(
+	args []string,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" ||
+		args[0] == "--help" {
+		usage(stderr)
+
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "spise: unknown command %q\n", args[0])
+		usage(stderr)
+
+		return 2
+	}
+	if cmd.main != nil {
+		return cmd.main(args[1:], stdout, stderr)
+	}
+
+	game := &domain.Game{}
+	var drawn cardsFlag
+	var path string
+	flags := flag.NewFlagSet("spise "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: spise %s [flags] %s\n", args[0], cmd.args)
+		flags.PrintDefaults()
+	}
+	gameFlags(flags, game)
+	flags.Var(
+		&drawn,
+		"drawn",
+		"comma separated `cards` already drawn this game",
+	)
+	flags.StringVar(
+		&path,
+		"game",
+		"",
+		"the `file` the game is loaded from and saved to",
+	)
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	sess, err := newSession(game, flags, path)
+	if err != nil {
+		fmt.Fprintf(stderr, "spise %s: %v\n", args[0], err)
+		if errors.Is(err, ErrUsage) {
+			flags.Usage()
+
+			return 2
+		}
+
+		return 1
+	}
+	sess.in, sess.out = stdin, stdout
+
+	for _, c := range drawn {
+		if err := sess.Draw(c); err != nil {
+			fmt.Fprintf(
+				stderr,
+				"spise: replaying drawn %s: %v\n",
+				c,
+				err,
+			)
+
+			return 1
+		}
+	}
+
+	err = cmd.run(sess, flags.Args())
+	if err == nil {
+		err = sess.save()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "spise %s: %v\n", args[0], err)
+		if errors.Is(err, ErrUsage) {
+			flags.Usage()
+
+			return 2
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+func gameFlags(flags *flag.FlagSet, game *domain.Game) {
+	flags.Var(
+		&adversaryFlag{&game.LeadingAdversary, &game.LeadingAdversaryLevel},
+		"leading",
+		"the leading `adversary:level`",
+	)
+	flags.Var(
+		&adversaryFlag{
+			&game.SupportingAdversary,
+			&game.SupportingAdversaryLevel,
+		},
+		"supporting",
+		"the supporting `adversary:level`",
+	)
+	adversaryFileFlag(flags)
+	names := domain.PredictorNames()
+	flags.Func(
+		"predictor",
+		"the `name` of the predictor, one of "+strings.Join(names, ", "),
+		func(name string) error {
+			for _, n := range names {
+				if n == name {
+					game.Predictor = name
+
+					return nil
+				}
+			}
+
+			return fmt.Errorf("%w: %w %q", ErrUsage, domain.ErrUnknownPredictor, name)
+		},
+	)
+	flags.Int64Var(
+		&game.Seed,
+		"seed",
+		0,
+		"the `seed` for random predictors and virtual decks",
+	)
+	flags.BoolVar(
+		&game.Virtual,
+		"virtual",
+		false,
+		"deal the invader cards instead of drawing physical ones",
+	)
+}
+
+func usage(out io.Writer) {
+	fmt.Fprintln(out, "usage: spise <command> [flags] [args]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "commands:")
+
+	names := make([]string, 0, len(commands))
+	for n := range commands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(
+			out,
+			"  %-20s %s\n",
+			strings.TrimSpace(n+" "+commands[n].args),
+			commands[n].summary,
+		)
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Cards are written as a stage and terrain initials,")
+	fmt.Fprintln(out, "e.g. 1J, 2C, 2SALT, 3MS, or 3? if the terrain is unknown.")
+	fmt.Fprintln(out, "Run 'spise <command> -h' for the flags of a command.")
+}
@@ -0,0 +1,577 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brycekbargar/spise/cli"
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+	}{
+		{
+			"New",
+			[]string{"new", "--leading", "russia:4"},
+			0,
+			"drawn:   \n" +
+				"in deck: 1? 1? 1? 2? 3?* 2? 3?* 2? 3?* 2? 3?* 3?\n",
+		},
+		{
+			"Setup",
+			[]string{"setup", "--leading", "scotland:2"},
+			0,
+			"stage I:   shuffle 4 cards and keep 3 face down, " +
+				"put 1 back in the box unseen\n" +
+				"stage II:  set aside 2C, shuffle 4 cards and keep 3 face down, " +
+				"put 1 back in the box unseen\n" +
+				"stage III: shuffle 6 cards and keep 5 face down, " +
+				"put 1 back in the box unseen\n" +
+				"stack the deck from the top:\n" +
+				"   1. a stage I card\n" +
+				"   2. a stage I card\n" +
+				"   3. a stage II card (specially placed)\n" +
+				"   4. a stage II card (specially placed)\n" +
+				"   5. a stage I card\n" +
+				"   6. 2C (specially placed)\n" +
+				"   7. a stage II card\n" +
+				"   8. a stage III card\n" +
+				"   9. a stage III card\n" +
+				"  10. a stage III card\n" +
+				"  11. a stage III card\n" +
+				"  12. a stage III card\n" +
+				"pattern: 1 1 2* 2* 1 2C* 2 3 3 3 3 3\n",
+		},
+		{
+			"Explain",
+			[]string{
+				"explain",
+				"--leading", "habsburg-mining-expedition:4",
+				"--supporting", "habsburg-livestock-colony:3",
+			},
+			0,
+			"habsburg-livestock-colony 3: Remove 1 additional Stage I card.\n" +
+				"  before: 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?\n" +
+				"  after:  1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?\n" +
+				"habsburg-mining-expedition 4: " +
+				"Place the Salt Deposits card in place of the 2nd Stage II card.\n" +
+				"  before: 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?\n" +
+				"  after:  1? 1? 2? 2SALT* 2? 2? 3? 3? 3? 3? 3?\n",
+		},
+		{
+			"ExplainBase",
+			[]string{"explain"},
+			0,
+			"the adversaries don't change the invader deck\n",
+		},
+		{
+			"Draw",
+			[]string{"draw", "1J", "1W"},
+			0,
+			"drawn:   1J* 1W*\n" +
+				"in deck: 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?\n",
+		},
+		{
+			"DrawEscalation",
+			[]string{"draw", "--leading", "england:1", "--drawn", "1J,1W,1S", "2M"},
+			0,
+			"england escalates on 2M: Building Boom: " +
+				"On each board with Town/City, " +
+				"Build in the land with the most Town/City.\n" +
+				"drawn:   1J* 1W* 1S* 2M*\n" +
+				"in deck: 2? 2? 2? 3? 3? 3? 3? 3?\n",
+		},
+		{
+			"DrawVirtual",
+			[]string{"draw", "--virtual", "--seed", "1"},
+			0,
+			"drew 1J\n" +
+				"drawn:   1J*\n" +
+				"in deck: 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?\n",
+		},
+		{
+			"Return",
+			[]string{
+				"return",
+				"--drawn", "1J,1W,1S",
+				"1S",
+			},
+			0,
+			"drawn:   1J* 1W* 2?*\n" +
+				"in deck: 1S 2? 2? 2? 3? 3? 3? 3? 3?\n",
+		},
+		{
+			"Entrench",
+			[]string{
+				"entrench",
+				"--leading", "sweden:0",
+				"--supporting", "russia:5",
+				"--drawn", "1J",
+				"2M",
+			},
+			0,
+			"drawn:   1J* 2M*\n" +
+				"in deck: 1? 1? 2? 3?* 2? 3?* 2? 3?* 2? 3?* 3?\n",
+		},
+		{
+			"Predict",
+			[]string{"predict", "--drawn", "1J,1W"},
+			0,
+			"next stage 1 card:\n" +
+				"  mountain        50%\n" +
+				"  sands           50%\n",
+		},
+		{
+			"PredictStage",
+			[]string{"predict", "--leading", "scotland:2", "2"},
+			0,
+			"next stage 2 card:\n" +
+				"  jungle          25%\n" +
+				"  mountain        25%\n" +
+				"  sands           25%\n" +
+				"  wetland         25%\n",
+		},
+		{
+			"Advance",
+			[]string{"advance", "--drawn", "1J"},
+			0,
+			"ravage:  \n" +
+				"build:   1J\n" +
+				"explore: \n",
+		},
+		{
+			"Track",
+			[]string{"track", "--drawn", "1J,1W"},
+			0,
+			"ravage:  \n" +
+				"build:   \n" +
+				"explore: 1J 1W\n" +
+				"next turn builds: 1J 1W\n",
+		},
+		{
+			"TrackHighImmigration",
+			[]string{"track", "--leading", "england:3", "--drawn", "1J,1W"},
+			0,
+			"high immigration: \n" +
+				"ravage:  \n" +
+				"build:   \n" +
+				"explore: 1J 1W\n" +
+				"next turn builds: 1J 1W\n",
+		},
+		{
+			"Forecast",
+			[]string{"forecast", "--drawn", "1J", "2"},
+			0,
+			"turn 1            ravage   build explore\n" +
+				"  jungle              0%    100%      0%\n" +
+				"  mountain            0%      0%     33%\n" +
+				"  sands               0%      0%     33%\n" +
+				"  wetland             0%      0%     33%\n" +
+				"turn 2            ravage   build explore\n" +
+				"  jungle            100%      0%      0%\n" +
+				"  mountain            0%     33%     33%\n" +
+				"  sands               0%     33%     33%\n" +
+				"  wetland             0%     33%     33%\n",
+		},
+		{
+			"Chance",
+			[]string{"chance", "--drawn", "1J", "mountain", "2"},
+			0,
+			"mountain in the next 2 cards: 66.7%\n",
+		},
+		{"BadChance", []string{"chance", "lava"}, 2, ""},
+		{"BadForecast", []string{"forecast", "0"}, 2, ""},
+		{
+			"PredictExact",
+			[]string{
+				"predict",
+				"--predictor", "exact",
+				"--drawn", "1J,1W,1S,2J",
+				"3",
+			},
+			0,
+			"next stage 3 card:\n" +
+				"  jungle          50%\n" +
+				"  mountain        50%\n" +
+				"  sands           50%\n" +
+				"  wetland         50%\n",
+		},
+		{"BadPredictor", []string{"predict", "--predictor", "psychic"}, 2, ""},
+		{
+			"Simulate",
+			[]string{"simulate", "--leading", "england:4", "--seed", "3", "10"},
+			0,
+			"10 games, 13.0 invader phases on average\n" +
+				"                  ravage   build explore\n" +
+				"  jungle            3.20    6.20    3.70\n" +
+				"  mountain          3.50    7.20    4.10\n" +
+				"  sands             3.60    7.20    4.00\n" +
+				"  wetland           3.70    7.40    4.20\n" +
+				"  coastal-lands     1.00    2.00    1.00\n",
+		},
+		{
+			"Difficulty",
+			[]string{"difficulty", "2"},
+			0,
+			"2          brandenburg-prussia:1\n" +
+				"2          france-plantation-colony:0\n" +
+				"2          habsburg-livestock-colony:0\n" +
+				"2          sweden:1\n",
+		},
+		{
+			"DifficultyRange",
+			[]string{"difficulty", "16.5", "19.25"},
+			0,
+			"16.5-19.25 england:6 supported by russia:6\n" +
+				"16.5-19.25 russia:6 supported by england:6\n",
+		},
+		{"NoDifficulty", []string{"difficulty", "0.5"}, 0, "no adversaries are within the difficulty\n"},
+		{"BadDifficulty", []string{"difficulty", "hard"}, 2, ""},
+		{"BackwardsDifficulty", []string{"difficulty", "5", "3"}, 2, ""},
+		{"NoCommand", []string{}, 2, ""},
+		{"UnknownCommand", []string{"shuffle"}, 2, ""},
+		{"BadFlag", []string{"new", "--leading", ":5"}, 2, ""},
+		{"BadCard", []string{"draw", "1C"}, 2, ""},
+		{"UnknownAdversary", []string{"new", "--leading", "atlantis"}, 2, ""},
+		{"BadLevel", []string{"new", "--leading", "russia:7"}, 2, ""},
+		{
+			"DuplicateAdversary",
+			[]string{"new", "--leading", "sweden", "--supporting", "sweden"},
+			2,
+			"",
+		},
+		{"NoLeadingAdversary", []string{"new", "--supporting", "sweden"}, 2, ""},
+		{"WrongStage", []string{"draw", "2J"}, 1, ""},
+		{"NotEntrenched", []string{"entrench", "2J"}, 1, ""},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run(tc.args, nil, &stdout, &stderr)
+			assert.Equal(t, tc.code, code, stderr.String())
+			assert.Equal(t, tc.stdout, stdout.String())
+			if tc.code != 0 {
+				assert.Assert(t, strings.TrimSpace(stderr.String()) != "")
+			}
+		})
+	}
+}
+
+func TestRun_Play(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		args   []string
+		stdin  []string
+		stdout []string
+	}{
+		{
+			"Scotland",
+			[]string{"play", "--leading", "scotland:2"},
+			[]string{
+				"draw 1J 1w",
+				"",
+				"draw 3JM",
+				"predict 3",
+				"shuffle",
+				"return 1W",
+				"undo",
+				"advance",
+				"quit",
+				"deck",
+			},
+			[]string{
+				"drawn:   ",
+				"in deck: 1? 1? 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: ",
+				"next stage 1 card:",
+				"  jungle          25%",
+				"  mountain        25%",
+				"  sands           25%",
+				"  wetland         25%",
+				"drawn:   1J* 1W*",
+				"in deck: 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: 1J 1W",
+				"next stage 2 card:",
+				"  jungle          25%",
+				"  mountain        25%",
+				"  sands           25%",
+				"  wetland         25%",
+				"error: drawing 3JM: " +
+					"expected a stage 2 invader card, not stage 3",
+				"next stage 3 card:",
+				"  jungle          50%",
+				"  mountain        50%",
+				"  sands           50%",
+				"  wetland         50%",
+				`unknown command "shuffle", try 'help'`,
+				"drawn:   1J* 2?*",
+				"in deck: 1W 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: 1J",
+				"next stage 1 card:",
+				"  wetland        100%",
+				"drawn:   1J* 1W*",
+				"in deck: 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: 1J 1W",
+				"next stage 2 card:",
+				"  jungle          25%",
+				"  mountain        25%",
+				"  sands           25%",
+				"  wetland         25%",
+				"drawn:   1J* 1W*",
+				"in deck: 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   1J 1W",
+				"explore: ",
+				"next stage 2 card:",
+				"  jungle          25%",
+				"  mountain        25%",
+				"  sands           25%",
+				"  wetland         25%",
+				"",
+			},
+		},
+		{
+			"Escalation",
+			[]string{"play", "--leading", "england:1"},
+			[]string{
+				"draw 1J 1M 1S",
+				"draw 2W",
+				"undo",
+				"quit",
+			},
+			[]string{
+				"drawn:   ",
+				"in deck: 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: ",
+				"next stage 1 card:",
+				"  jungle          25%",
+				"  mountain        25%",
+				"  sands           25%",
+				"  wetland         25%",
+				"drawn:   1J* 1M* 1S*",
+				"in deck: 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: 1J 1M 1S",
+				"next stage 2 card:",
+				"  jungle          20%",
+				"  mountain        20%",
+				"  sands           20%",
+				"  wetland         20%",
+				"  coastal-lands   20%",
+				"england escalates on 2W: Building Boom: " +
+					"On each board with Town/City, " +
+					"Build in the land with the most Town/City.",
+				"drawn:   1J* 1M* 1S* 2W*",
+				"in deck: 2? 2? 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: 1J 1M 1S 2W",
+				"next stage 2 card:",
+				"  jungle          25%",
+				"  mountain        25%",
+				"  sands           25%",
+				"  coastal-lands   25%",
+				"drawn:   1J* 1M* 1S*",
+				"in deck: 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+				"ravage:  ",
+				"build:   ",
+				"explore: 1J 1M 1S",
+				"next stage 2 card:",
+				"  jungle          20%",
+				"  mountain        20%",
+				"  sands           20%",
+				"  wetland         20%",
+				"  coastal-lands   20%",
+				"",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			code := cli.Run(
+				tc.args,
+				strings.NewReader(strings.Join(tc.stdin, "\n")),
+				&stdout,
+				&stderr,
+			)
+			assert.Equal(t, 0, code, stderr.String())
+			assert.Equal(t, strings.Join(tc.stdout, "\n"), stdout.String())
+		})
+	}
+}
+
+func TestRun_Game(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "game.json")
+	for _, args := range [][]string{
+		{"new", "--game", path, "--leading", "russia:5"},
+		{"draw", "--game", path, "1J", "1W"},
+		{"entrench", "--game", path, "2M"},
+		{"undo", "--game", path},
+		{"undo", "--game", path},
+		{"redo", "--game", path},
+		{"redo", "--game", path},
+	} {
+		var stdout, stderr bytes.Buffer
+		code := cli.Run(args, nil, &stdout, &stderr)
+		assert.Equal(t, 0, code, stderr.String())
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(
+		[]string{"status", "--game", path},
+		nil,
+		&stdout,
+		&stderr,
+	)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t,
+		"drawn:   1J* 1W* 2M*\n"+
+			"in deck: 1? 2? 3?* 2? 3?* 2? 3?* 2? 3?* 3?\n",
+		stdout.String())
+
+	stdout.Reset()
+	code = cli.Run(
+		[]string{"status", "--game", path, "--leading", "scotland:2"},
+		nil,
+		&stdout,
+		&stderr,
+	)
+	assert.Equal(t, 2, code)
+}
+
+func TestRun_VirtualSeed(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	seed := func(args ...string) int64 {
+		t.Helper()
+
+		path := filepath.Join(dir, "game"+strings.Join(args, ""))
+		var stdout, stderr bytes.Buffer
+		code := cli.Run(
+			append([]string{"new", "--game", path}, args...),
+			nil,
+			&stdout,
+			&stderr,
+		)
+		assert.Equal(t, 0, code, stderr.String())
+
+		saved, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		game := &domain.InitializedGame{}
+		assert.NilError(t, json.Unmarshal(saved, game))
+
+		return game.Seed
+	}
+
+	assert.Equal(t, int64(0), seed())
+	assert.Equal(t, int64(3), seed("--virtual", "--seed", "3"))
+
+	// Unseeded virtual games get their own seed.
+	first, second := seed("--virtual"), seed("--virtual", "--seed", "0")
+	assert.Assert(t, first != 0)
+	assert.Assert(t, first != second)
+
+	// So do saved games which become virtual.
+	path := filepath.Join(dir, "game")
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(
+		[]string{"status", "--game", path, "--virtual"},
+		nil,
+		&stdout,
+		&stderr,
+	)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Assert(t, seed() != 0)
+}
+
+func TestRun_AdversaryFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "homebrew.toml")
+	assert.NilError(t, os.WriteFile(valid, []byte(
+		"name = \"homebrew-cli\"\n"+
+			"maxLevel = 1\n"+
+			"\n"+
+			"[[deck]]\n"+
+			"level = 1\n"+
+			"text = \"Remove all Stage I cards.\"\n"+
+			"ops = [\"remove-all 1\"]\n",
+	), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(
+		[]string{
+			"new",
+			"--adversary-file", valid,
+			"--leading", "homebrew-cli:1",
+		},
+		nil,
+		&stdout,
+		&stderr,
+	)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Equal(t,
+		"drawn:   \n"+
+			"in deck: 2? 2? 2? 2? 3? 3? 3? 3? 3?\n",
+		stdout.String())
+
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NilError(t, os.WriteFile(invalid, []byte(
+		"{\n"+
+			"  \"name\": \"homebrew-invalid\",\n"+
+			"  \"deck\": [{\"level\": 0, \"ops\": [\"remove-nth 1 0\"]}]\n"+
+			"}\n",
+	), 0o600))
+
+	stdout.Reset()
+	stderr.Reset()
+	code = cli.Run(
+		[]string{"new", "--adversary-file", invalid},
+		nil,
+		&stdout,
+		&stderr,
+	)
+	assert.Equal(t, 2, code)
+	assert.Assert(t, strings.Contains(
+		stderr.String(),
+		invalid+`: line 3: remove-nth: "0" is not a card number`,
+	), stderr.String())
+}
@@ -0,0 +1,387 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+// ErrEmptyDeck occurs when a prediction is requested after the last card.
+var ErrEmptyDeck = errors.New("there are no more cards to predict")
+
+func runStatus(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	printDeck(sess.out, sess.InvaderDeck())
+
+	return nil
+}
+
+func runDraw(
+	sess *session,
+	args []string,
+) error {
+	escalated := len(sess.Escalations())
+	if len(args) == 0 && sess.Virtual {
+		c, err := sess.DrawNext()
+		if err != nil {
+			return fmt.Errorf("drawing: %w", err)
+		}
+		fmt.Fprintf(sess.out, "drew %s\n", c)
+		printEscalations(sess.out, sess.Escalations()[escalated:])
+		printDeck(sess.out, sess.InvaderDeck())
+
+		return nil
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected at least one card", ErrUsage)
+	}
+
+	for _, a := range args {
+		c, err := parseCard(a)
+		if err != nil {
+			return err
+		}
+		if err := sess.Draw(c); err != nil {
+			return fmt.Errorf("drawing %s: %w", a, err)
+		}
+	}
+
+	printEscalations(sess.out, sess.Escalations()[escalated:])
+	printDeck(sess.out, sess.InvaderDeck())
+
+	return nil
+}
+
+// printEscalations prints the Escalation effects the players need to resolve.
+func printEscalations(out io.Writer, escs []domain.Escalation) {
+	for _, e := range escs {
+		fmt.Fprintf(out, "%s escalates on %s: %s\n", e.Adversary, e.Card, e.Text)
+	}
+}
+
+func runReturn(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected one card", ErrUsage)
+	}
+
+	c, err := parseCard(args[0])
+	if err != nil {
+		return err
+	}
+	if err := sess.Return(c); err != nil {
+		return fmt.Errorf("returning %s: %w", args[0], err)
+	}
+
+	printDeck(sess.out, sess.InvaderDeck())
+
+	return nil
+}
+
+func runEntrench(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected one card", ErrUsage)
+	}
+
+	c, err := parseCard(args[0])
+	if err != nil {
+		return err
+	}
+	if err := sess.Entrenched(c); err != nil {
+		return fmt.Errorf("entrenching %s: %w", args[0], err)
+	}
+
+	printDeck(sess.out, sess.InvaderDeck())
+
+	return nil
+}
+
+func runUndo(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	if err := sess.Undo(); err != nil {
+		return err
+	}
+
+	printDeck(sess.out, sess.InvaderDeck())
+
+	return nil
+}
+
+func runRedo(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	if err := sess.Redo(); err != nil {
+		return err
+	}
+
+	printDeck(sess.out, sess.InvaderDeck())
+
+	return nil
+}
+
+func runAdvance(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	if err := sess.Advance(); err != nil {
+		return err
+	}
+
+	printTrack(sess.out, sess.InvaderTrack())
+
+	return nil
+}
+
+func runTrack(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	track := sess.InvaderTrack()
+	printTrack(sess.out, track)
+	fmt.Fprintf(sess.out, "next turn builds: %s\n", joinCards(track.NextBuilds()))
+
+	return nil
+}
+
+func runPredict(
+	sess *session,
+	args []string,
+) error {
+	var stage int
+	switch len(args) {
+	case 0:
+		deck := sess.InvaderDeck()
+		if len(deck.InDeck) == 0 {
+			return ErrEmptyDeck
+		}
+		stage = deck.InDeck[0].Stage
+	case 1:
+		s, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a stage", ErrUsage, args[0])
+		}
+		stage = s
+	default:
+		return fmt.Errorf("%w: expected at most one stage", ErrUsage)
+	}
+
+	pcts, err := sess.PredictStage(stage)
+	if err != nil {
+		return err
+	}
+
+	printPredictions(sess.out, stage, pcts)
+
+	return nil
+}
+
+func runForecast(
+	sess *session,
+	args []string,
+) error {
+	turns := 3
+	switch len(args) {
+	case 0:
+	case 1:
+		t, err := strconv.Atoi(args[0])
+		if err != nil || t < 1 {
+			return fmt.Errorf(
+				"%w: %q is not a number of turns",
+				ErrUsage,
+				args[0],
+			)
+		}
+		turns = t
+	default:
+		return fmt.Errorf("%w: expected at most one number of turns", ErrUsage)
+	}
+
+	forecasts, err := sess.Forecast(turns)
+	if err != nil {
+		return err
+	}
+
+	printForecasts(sess.out, forecasts)
+
+	return nil
+}
+
+func runChance(
+	sess *session,
+	args []string,
+) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("%w: expected a terrain and number of cards", ErrUsage)
+	}
+
+	trn, err := parseTerrain(args[0])
+	if err != nil {
+		return err
+	}
+	cards := 1
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf(
+				"%w: %q is not a number of cards",
+				ErrUsage,
+				args[1],
+			)
+		}
+		cards = n
+	}
+
+	positions := make([]int, 0, cards)
+	for i := 0; i < cards; i++ {
+		positions = append(positions, i)
+	}
+	chance := sess.ExactPredictor().Chance(trn, positions...)
+	fmt.Fprintf(
+		sess.out,
+		"%s in the next %d cards: %.1f%%\n",
+		trn,
+		cards,
+		chance*100,
+	)
+
+	return nil
+}
+
+// parseTerrain parses the terrain from its name or initial.
+func parseTerrain(s string) (domain.Terrain, error) {
+	for _, t := range domain.AllTerrains {
+		if strings.EqualFold(s, string(t)) ||
+			strings.EqualFold(s, string(t)[:1]) {
+			return t, nil
+		}
+	}
+
+	return domain.UnknownTerrain, fmt.Errorf(
+		"%w: %q is not a terrain",
+		ErrUsage,
+		s,
+	)
+}
+
+// parseCard parses the card from the compact notation.
+func parseCard(s string) (domain.InvaderCard, error) {
+	c, err := domain.ParseInvaderCard(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	return c, nil
+}
+
+func printDeck(out io.Writer, deck domain.InvaderDeck) {
+	drawn := make([]string, 0, len(deck.Drawn))
+	for _, c := range deck.Drawn {
+		drawn = append(drawn, c.String())
+	}
+
+	indeck := make([]string, 0, len(deck.InDeck))
+	for _, c := range deck.InDeck {
+		indeck = append(indeck, c.String())
+	}
+
+	fmt.Fprintf(out, "drawn:   %s\n", strings.Join(drawn, " "))
+	fmt.Fprintf(out, "in deck: %s\n", strings.Join(indeck, " "))
+}
+
+func printTrack(out io.Writer, track domain.InvaderTrack) {
+	if track.HighImmigration != nil {
+		fmt.Fprintf(
+			out,
+			"high immigration: %s\n",
+			joinCards(track.HighImmigration),
+		)
+	}
+	fmt.Fprintf(out, "ravage:  %s\n", joinCards(track.Ravage))
+	fmt.Fprintf(out, "build:   %s\n", joinCards(track.Build))
+	fmt.Fprintf(out, "explore: %s\n", joinCards(track.Explore))
+}
+
+func joinCards(cards []domain.InvaderCard) string {
+	strs := make([]string, 0, len(cards))
+	for _, c := range cards {
+		strs = append(strs, c.String())
+	}
+
+	return strings.Join(strs, " ")
+}
+
+func printPredictions(
+	out io.Writer,
+	stage int,
+	pcts map[domain.Terrain]float64,
+) {
+	fmt.Fprintf(out, "next stage %d card:\n", stage)
+	for _, t := range domain.AllTerrains {
+		p, ok := pcts[t]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(out, "  %-14s %3.0f%%\n", t, p*100)
+	}
+}
+
+func printForecasts(out io.Writer, forecasts []domain.Forecast) {
+	for i, fc := range forecasts {
+		fmt.Fprintf(
+			out,
+			"turn %-11d %7s %7s %7s\n",
+			i+1,
+			"ravage",
+			"build",
+			"explore",
+		)
+		for _, t := range domain.AllTerrains {
+			r, b, e := fc.Ravage[t], fc.Build[t], fc.Explore[t]
+			if r == 0 && b == 0 && e == 0 {
+				continue
+			}
+			fmt.Fprintf(
+				out,
+				"  %-14s %6.0f%% %6.0f%% %6.0f%%\n",
+				t,
+				r*100,
+				b*100,
+				e*100,
+			)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+// difficulty lists every game with a difficulty in the target range.
+func difficulty(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("spise difficulty", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: spise difficulty [flags] MIN [MAX]")
+		flags.PrintDefaults()
+	}
+	adversaryFileFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	lo, hi, err := difficultyRange(flags.Args())
+	if err == nil {
+		err = printDifficulties(stdout, lo, hi)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "spise difficulty: %v\n", err)
+		if errors.Is(err, ErrUsage) {
+			flags.Usage()
+
+			return 2
+		}
+
+		return 1
+	}
+
+	return 0
+}
+
+func difficultyRange(args []string) (float64, float64, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return 0, 0, fmt.Errorf(
+			"%w: expected a minimum and maximum difficulty",
+			ErrUsage,
+		)
+	}
+
+	bounds := make([]float64, 0, 2)
+	for _, a := range args {
+		d, err := strconv.ParseFloat(a, 64)
+		if err != nil || d < 0 {
+			return 0, 0, fmt.Errorf("%w: %q is not a difficulty", ErrUsage, a)
+		}
+		bounds = append(bounds, d)
+	}
+	if len(bounds) == 1 {
+		bounds = append(bounds, bounds[0])
+	}
+	if bounds[1] < bounds[0] {
+		return 0, 0, fmt.Errorf(
+			"%w: %g is less than %g",
+			ErrUsage,
+			bounds[1],
+			bounds[0],
+		)
+	}
+
+	return bounds[0], bounds[1], nil
+}
+
+type difficultyGame struct {
+	game domain.Game
+	diff domain.Difficulty
+}
+
+// printDifficulties prints the games which are entirely within the range,
+// easiest first. Adversaries without difficulty data are left out.
+func printDifficulties(out io.Writer, lo float64, hi float64) error {
+	type level struct {
+		adversary domain.Adversary
+		level     int
+	}
+	levels := []level{}
+	for _, n := range domain.AdversaryNames() {
+		def, ok := domain.LookupAdversary(n)
+		if !ok || len(def.Difficulty) == 0 {
+			continue
+		}
+		for l := def.MinLevel; l <= def.MaxLevel; l++ {
+			levels = append(levels, level{n, l})
+		}
+	}
+
+	games := []difficultyGame{}
+	add := func(game domain.Game) error {
+		diff, err := game.Difficulty()
+		if err != nil {
+			return err
+		}
+		if diff.Min >= lo && diff.Max <= hi {
+			games = append(games, difficultyGame{game, diff})
+		}
+
+		return nil
+	}
+	for _, l := range levels {
+		if err := add(domain.Game{
+			LeadingAdversary:      l.adversary,
+			LeadingAdversaryLevel: l.level,
+		}); err != nil {
+			return err
+		}
+		for _, s := range levels {
+			if s.adversary == l.adversary {
+				continue
+			}
+			if err := add(domain.Game{
+				LeadingAdversary:         l.adversary,
+				LeadingAdversaryLevel:    l.level,
+				SupportingAdversary:      s.adversary,
+				SupportingAdversaryLevel: s.level,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The games are already sorted by adversary and level.
+	sort.SliceStable(games, func(i, j int) bool {
+		if games[i].diff.Min != games[j].diff.Min {
+			return games[i].diff.Min < games[j].diff.Min
+		}
+
+		return games[i].diff.Max < games[j].diff.Max
+	})
+
+	if len(games) == 0 {
+		fmt.Fprintln(out, "no adversaries are within the difficulty")
+
+		return nil
+	}
+	for _, g := range games {
+		fmt.Fprintf(
+			out,
+			"%-10s %s:%d",
+			g.diff,
+			g.game.LeadingAdversary,
+			g.game.LeadingAdversaryLevel,
+		)
+		if g.game.SupportingAdversary != domain.UnknownAdversary {
+			fmt.Fprintf(
+				out,
+				" supported by %s:%d",
+				g.game.SupportingAdversary,
+				g.game.SupportingAdversaryLevel,
+			)
+		}
+		fmt.Fprintln(out)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+// adversaryFlag parses an adversary:level pair into a domain.Game.
+type adversaryFlag struct {
+	adversary *domain.Adversary
+	level     *int
+}
+
+func (af *adversaryFlag) String() string {
+	if af.adversary == nil || *af.adversary == domain.UnknownAdversary {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d", *af.adversary, *af.level)
+}
+
+func (af *adversaryFlag) Set(value string) error {
+	name, lvl, found := strings.Cut(value, ":")
+	if name == "" {
+		return fmt.Errorf("%w: %q has no adversary", ErrUsage, value)
+	}
+
+	*af.adversary = domain.Adversary(name)
+	*af.level = 0
+	if !found {
+		return nil
+	}
+
+	l, err := strconv.Atoi(lvl)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not a level", ErrUsage, lvl)
+	}
+	*af.level = l
+
+	return nil
+}
+
+// adversaryFileFlag registers each homebrew adversary file as it's parsed
+// so the adversary can be used by the flags after it.
+func adversaryFileFlag(flags *flag.FlagSet) {
+	flags.Func(
+		"adversary-file",
+		"load a homebrew adversary from a TOML or JSON `file`",
+		loadAdversaryFile,
+	)
+}
+
+// loadAdversaryFile registers the adversary defined in a TOML or JSON file.
+func loadAdversaryFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var def domain.AdversaryDefinition
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		def, err = domain.UnmarshalAdversaryTOML(data)
+	case ".json":
+		def, err = domain.UnmarshalAdversaryJSON(data)
+	default:
+		return fmt.Errorf(
+			"%w: %s is not a .toml or .json file",
+			ErrUsage,
+			path,
+		)
+	}
+	if err == nil {
+		err = domain.RegisterAdversary(def)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// cardsFlag parses a comma separated list of invader cards.
+type cardsFlag []domain.InvaderCard
+
+func (cf *cardsFlag) String() string {
+	strs := make([]string, 0, len(*cf))
+	for _, c := range *cf {
+		strs = append(strs, c.String())
+	}
+
+	return strings.Join(strs, ",")
+}
+
+func (cf *cardsFlag) Set(value string) error {
+	for _, s := range strings.Split(value, ",") {
+		c, err := domain.ParseInvaderCard(s)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrUsage, err)
+		}
+		*cf = append(*cf, c)
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const prompt = "spise> "
+
+type action struct {
+	args    string
+	summary string
+	run     func(sess *session, args []string) error
+	// mutates is true when the game changes and the turn should be shown.
+	mutates bool
+}
+
+var actions = map[string]action{
+	"draw": {
+		"[CARD...]",
+		"draw one or more invader cards (or deal one when virtual)",
+		runDraw,
+		true,
+	},
+	"return": {
+		"CARD",
+		"return a drawn card to the top of the deck",
+		runReturn,
+		true,
+	},
+	"entrench": {
+		"CARD",
+		"add a Stage II or III card to the discard",
+		runEntrench,
+		true,
+	},
+	"advance": {
+		"",
+		"advance the invader track at the end of the Invader Phase",
+		runAdvance,
+		true,
+	},
+	"undo": {
+		"",
+		"undo the last change to the game",
+		runUndo,
+		true,
+	},
+	"redo": {
+		"",
+		"redo the last undone change to the game",
+		runRedo,
+		true,
+	},
+	"predict": {
+		"[STAGE]",
+		"predict the terrain of the next card (of the given stage)",
+		runPredict,
+		false,
+	},
+	"chance": {
+		"TERRAIN [CARDS]",
+		"the exact chance of a terrain in the next cards",
+		runChance,
+		false,
+	},
+	"forecast": {
+		"[TURNS]",
+		"forecast the terrains of the next invader phases",
+		runForecast,
+		false,
+	},
+	"deck": {
+		"",
+		"show the invader deck",
+		runStatus,
+		false,
+	},
+	"track": {
+		"",
+		"show what ravages, builds, and explores this turn",
+		runTrack,
+		false,
+	},
+}
+
+// lineReader reads a single line of input from the player.
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+type scannedLines struct {
+	*bufio.Scanner
+}
+
+func (sl scannedLines) ReadLine() (string, error) {
+	if sl.Scan() {
+		return sl.Text(), nil
+	}
+	if err := sl.Err(); err != nil {
+		return "", err
+	}
+
+	return "", io.EOF
+}
+
+func runPlay(sess *session, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	var lines lineReader = scannedLines{bufio.NewScanner(sess.in)}
+	if f, ok := sess.in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		//nolint:errcheck // there's nothing to do if it can't be restored
+		defer term.Restore(int(f.Fd()), state)
+
+		// The terminal provides line editing and history.
+		t := term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{sess.in, sess.out}, prompt)
+		lines = t
+		sess = &session{sess.InitializedGame, sess.in, t, sess.path}
+	}
+
+	return play(sess, lines)
+}
+
+func play(sess *session, lines lineReader) error {
+	printTurn(sess)
+
+	for {
+		line, err := lines.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "quit", "exit":
+			return nil
+		case "help", "?":
+			playHelp(sess.out)
+
+			continue
+		}
+
+		act, ok := actions[fields[0]]
+		if !ok {
+			fmt.Fprintf(
+				sess.out,
+				"unknown command %q, try 'help'\n",
+				fields[0],
+			)
+
+			continue
+		}
+
+		if !act.mutates {
+			if err := act.run(sess, fields[1:]); err != nil {
+				fmt.Fprintf(sess.out, "error: %v\n", err)
+			}
+
+			continue
+		}
+
+		escalated := len(sess.Escalations())
+		if err := act.run(sess.quiet(), fields[1:]); err != nil {
+			fmt.Fprintf(sess.out, "error: %v\n", err)
+
+			continue
+		}
+		if err := sess.save(); err != nil {
+			fmt.Fprintf(sess.out, "error saving: %v\n", err)
+		}
+		// Undoing a draw removes its Escalations, they aren't shown again.
+		if escs := sess.Escalations(); len(escs) > escalated {
+			printEscalations(sess.out, escs[escalated:])
+		}
+		printTurn(sess)
+	}
+}
+
+// printTurn shows the deck, the track, and the predictions for the next card.
+func printTurn(sess *session) {
+	printDeck(sess.out, sess.InvaderDeck())
+	printTrack(sess.out, sess.InvaderTrack())
+	if err := runPredict(sess, nil); err != nil {
+		fmt.Fprintf(sess.out, "%v\n", err)
+	}
+}
+
+func playHelp(out io.Writer) {
+	fmt.Fprintln(out, "commands:")
+	for _, n := range []string{
+		"draw",
+		"return",
+		"entrench",
+		"advance",
+		"undo",
+		"redo",
+		"predict",
+		"chance",
+		"forecast",
+		"deck",
+		"track",
+	} {
+		fmt.Fprintf(
+			out,
+			"  %-20s %s\n",
+			strings.TrimSpace(n+" "+actions[n].args),
+			actions[n].summary,
+		)
+	}
+	fmt.Fprintf(out, "  %-20s %s\n", "help", "show this help")
+	fmt.Fprintf(out, "  %-20s %s\n", "quit", "end the session")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Cards are written as a stage and terrain initials,")
+	fmt.Fprintln(out, "e.g. 1J, 2C, 2SALT, 3MS, or 3? if the terrain is unknown.")
+}
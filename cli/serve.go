@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/brycekbargar/spise/server"
+)
+
+func serve(args []string, stdout io.Writer, stderr io.Writer) int {
+	var addr string
+	flags := flag.NewFlagSet("spise serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&addr, "addr", ":8080", "the `address` to listen on")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		fmt.Fprintf(
+			stderr,
+			"spise serve: unexpected arguments %v\n",
+			flags.Args(),
+		)
+		flags.Usage()
+
+		return 2
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           server.New(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(stdout, "serving games on %s\n", addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(stderr, "spise serve: %v\n", err)
+
+		return 1
+	}
+
+	return 0
+}
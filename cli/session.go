@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+// session is the game and io for a single invocation of spise.
+type session struct {
+	*domain.InitializedGame
+
+	in  io.Reader
+	out io.Writer
+	// path is the file the game is saved to, if any.
+	path string
+}
+
+// newSession loads the game from path if it exists,
+// otherwise it initializes the game from the flags.
+func newSession(
+	game *domain.Game,
+	flags *flag.FlagSet,
+	path string,
+) (*session, error) {
+	sess := &session{path: path}
+	if path == "" {
+		return sess, sess.init(game)
+	}
+
+	saved, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return sess, sess.init(game)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	adversaries := false
+	flags.Visit(func(f *flag.Flag) {
+		adversaries = adversaries ||
+			f.Name == "leading" || f.Name == "supporting"
+	})
+	if adversaries {
+		return nil, fmt.Errorf(
+			"%w: the adversaries of %s are already set",
+			ErrUsage,
+			path,
+		)
+	}
+
+	sess.InitializedGame = &domain.InitializedGame{}
+	if err := json.Unmarshal(saved, sess.InitializedGame); err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+
+	// The predictor and deck can be changed for any invocation.
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "predictor":
+			sess.Game.Predictor = game.Predictor
+		case "seed":
+			sess.Game.Seed = game.Seed
+		case "virtual":
+			sess.Game.Virtual = game.Virtual
+		}
+	})
+	sess.Game.SeedVirtual()
+
+	return sess, nil
+}
+
+// init initializes a new game, invalid games are a usage error.
+func (sess *session) init(game *domain.Game) error {
+	init, err := game.Init()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	sess.InitializedGame = init
+
+	return nil
+}
+
+// save writes the game to the session's file, if it has one.
+func (sess *session) save() error {
+	if sess.path == "" {
+		return nil
+	}
+
+	saved, err := json.MarshalIndent(sess.InitializedGame, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failure can't lose the game.
+	tmp, err := os.CreateTemp(filepath.Dir(sess.path), ".spise-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(saved, '\n')); err != nil {
+		tmp.Close()
+
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), sess.path)
+}
+
+// quiet is the session without any output.
+func (sess *session) quiet() *session {
+	q := *sess
+	q.out = io.Discard
+
+	return &q
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+// stageNames are the roman numerals printed on the back of each stage.
+var stageNames = map[int]string{1: "I", 2: "II", 3: "III"}
+
+func runSetup(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	printSetup(sess.out, domain.NewInvaderDeckSetup(sess.Game))
+
+	return nil
+}
+
+func printSetup(out io.Writer, setup domain.InvaderDeckSetup) {
+	for _, ss := range setup.Stages {
+		steps := make([]string, 0, 4)
+		for _, c := range ss.Removed {
+			steps = append(steps, fmt.Sprintf("put %s back in the box", c))
+		}
+		for _, c := range ss.SetAside {
+			steps = append(steps, fmt.Sprintf("set aside %s", c))
+		}
+		switch unused := ss.Shuffled - ss.Kept; {
+		case ss.Kept == 0:
+			steps = append(steps, fmt.Sprintf(
+				"put all %d back in the box",
+				ss.Shuffled,
+			))
+		case unused > 0:
+			steps = append(steps, fmt.Sprintf(
+				"shuffle %d cards and keep %d face down, "+
+					"put %d back in the box unseen",
+				ss.Shuffled,
+				ss.Kept,
+				unused,
+			))
+		default:
+			steps = append(steps, fmt.Sprintf(
+				"shuffle %d cards",
+				ss.Shuffled,
+			))
+		}
+		fmt.Fprintf(
+			out,
+			"%-10s %s\n",
+			"stage "+stageNames[ss.Stage]+":",
+			strings.Join(steps, ", "),
+		)
+	}
+
+	fmt.Fprintln(out, "stack the deck from the top:")
+	pattern := make([]string, 0, len(setup.Deck))
+	for i, c := range setup.Deck {
+		card := "a stage " + stageNames[c.Stage] + " card"
+		if c.Terrain != domain.UnknownTerrain {
+			card = c.InvaderCard.String()
+		}
+		if c.SpeciallyPlaced {
+			card += " (specially placed)"
+		}
+		fmt.Fprintf(out, "  %2d. %s\n", i+1, card)
+		p := strings.TrimSuffix(c.InvaderCard.String(), "?")
+		if c.SpeciallyPlaced {
+			p += "*"
+		}
+		pattern = append(pattern, p)
+	}
+	fmt.Fprintf(out, "pattern: %s\n", strings.Join(pattern, " "))
+}
+
+func runExplain(
+	sess *session,
+	args []string,
+) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", ErrUsage, args)
+	}
+
+	_, trace := domain.TraceInvaderDeck(sess.Game)
+	if len(trace) == 0 {
+		fmt.Fprintln(sess.out, "the adversaries don't change the invader deck")
+
+		return nil
+	}
+	for _, mod := range trace {
+		fmt.Fprintf(sess.out, "%s %d: %s\n", mod.Adversary, mod.Level, mod.Rule)
+		fmt.Fprintf(sess.out, "  before: %s\n", joinDeck(mod.Before))
+		fmt.Fprintf(sess.out, "  after:  %s\n", joinDeck(mod.After))
+	}
+
+	return nil
+}
+
+func joinDeck(deck []domain.InvaderCardInDeck) string {
+	strs := make([]string, 0, len(deck))
+	for _, c := range deck {
+		strs = append(strs, c.String())
+	}
+
+	return strings.Join(strs, " ")
+}
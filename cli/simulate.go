@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/brycekbargar/spise/domain"
+	"github.com/brycekbargar/spise/simulator"
+)
+
+func runSimulate(
+	sess *session,
+	args []string,
+) error {
+	runs := 10000
+	switch len(args) {
+	case 0:
+	case 1:
+		r, err := strconv.Atoi(args[0])
+		if err != nil || r < 1 {
+			return fmt.Errorf(
+				"%w: %q is not a number of runs",
+				ErrUsage,
+				args[0],
+			)
+		}
+		runs = r
+	default:
+		return fmt.Errorf("%w: expected at most one number of runs", ErrUsage)
+	}
+
+	res, err := simulator.Run(sess.Game, simulator.Options{
+		Runs: runs,
+		Seed: sess.Seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	printResults(sess.out, res)
+
+	return nil
+}
+
+func printResults(out io.Writer, res simulator.Results) {
+	fmt.Fprintf(
+		out,
+		"%d games, %.1f invader phases on average\n",
+		res.Runs,
+		res.Phases,
+	)
+	fmt.Fprintf(
+		out,
+		"%-16s %7s %7s %7s\n",
+		"",
+		"ravage",
+		"build",
+		"explore",
+	)
+	for _, t := range domain.AllTerrains {
+		r, b, e := res.Ravage[t], res.Build[t], res.Explore[t]
+		if r == 0 && b == 0 && e == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "  %-14s %7.2f %7.2f %7.2f\n", t, r, b, e)
+	}
+}
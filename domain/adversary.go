@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
 // Adversaries are optional personalities for the faceless Invaders.
 type Adversary string
 
@@ -23,3 +30,314 @@ const (
 	// The Kingdom of Sweden.
 	Sweden Adversary = "sweden"
 )
+
+// ErrInvalidAdversary occurs when an adversary definition can't be used.
+var ErrInvalidAdversary = errors.New("invalid adversary")
+
+// AdversaryRule is an adversary effect which changes how the invader cards
+// are tracked after setup.
+type AdversaryRule string
+
+const (
+	// HighImmigrationRule adds England's High Immigration tile.
+	HighImmigrationRule AdversaryRule = "high-immigration"
+	// PermanentHighImmigrationRule keeps the tile for the whole game.
+	PermanentHighImmigrationRule AdversaryRule = "permanent-high-immigration"
+	// EntrenchedRule lets Stage II and III cards be added to the discard.
+	EntrenchedRule AdversaryRule = "entrenched"
+)
+
+// AdversaryDefinition is everything spise tracks about an adversary.
+type AdversaryDefinition struct {
+	Name Adversary
+	// MinLevel and MaxLevel are the levels the adversary can be played at.
+	MinLevel int
+	MaxLevel int
+	// Difficulty is the difficulty of each level starting at level 0.
+	Difficulty []int
+	// Escalation is the effect of the adversary's escalation icon.
+	Escalation string
+	// DeckRules change the invader deck during setup.
+	DeckRules []DeckRule
+	// Reveals are the known cards which aren't shuffled during setup.
+	Reveals []Reveal
+	// Rules are the level each other rule of the adversary starts at.
+	Rules map[AdversaryRule]int
+}
+
+// Reveal is a card known from setup when played at or above the level.
+type Reveal struct {
+	Level int
+	Card  InvaderCard
+}
+
+var (
+	adversariesMu sync.RWMutex
+	adversaries   = func() map[Adversary]AdversaryDefinition {
+		defs := make(map[Adversary]AdversaryDefinition, len(builtinAdversaries))
+		for _, def := range builtinAdversaries {
+			defs[def.Name] = def
+		}
+
+		return defs
+	}()
+)
+
+// RegisterAdversary makes the adversary available to games by name.
+// Registering a name again replaces the previous definition.
+func RegisterAdversary(def AdversaryDefinition) error {
+	if def.Name == UnknownAdversary {
+		return fmt.Errorf("%w: the adversary has no name", ErrInvalidAdversary)
+	}
+	if def.MinLevel < 0 || def.MaxLevel < def.MinLevel {
+		return fmt.Errorf(
+			"%w: %s has levels %d to %d",
+			ErrInvalidAdversary,
+			def.Name,
+			def.MinLevel,
+			def.MaxLevel,
+		)
+	}
+	if len(def.Difficulty) != 0 && len(def.Difficulty) != def.MaxLevel+1 {
+		return fmt.Errorf(
+			"%w: %s has %d difficulties for levels 0 to %d",
+			ErrInvalidAdversary,
+			def.Name,
+			len(def.Difficulty),
+			def.MaxLevel,
+		)
+	}
+	for _, r := range def.Reveals {
+		if !isStandard(r.Card) {
+			return fmt.Errorf(
+				"%w: %s reveals %w",
+				ErrInvalidAdversary,
+				def.Name,
+				UnknownCardError{r.Card},
+			)
+		}
+	}
+
+	adversariesMu.Lock()
+	defer adversariesMu.Unlock()
+
+	adversaries[def.Name] = def
+
+	return nil
+}
+
+// LookupAdversary finds the definition of a registered adversary.
+func LookupAdversary(name Adversary) (AdversaryDefinition, bool) {
+	adversariesMu.RLock()
+	defer adversariesMu.RUnlock()
+
+	def, ok := adversaries[name]
+
+	return def, ok
+}
+
+// AdversaryNames are the names of every registered adversary, sorted.
+func AdversaryNames() []Adversary {
+	adversariesMu.RLock()
+	defer adversariesMu.RUnlock()
+
+	names := make([]Adversary, 0, len(adversaries))
+	for n := range adversaries {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	return names
+}
+
+// playedAdversary is a registered adversary at the level it is played.
+type playedAdversary struct {
+	AdversaryDefinition
+	level int
+}
+
+// adversaries are the registered adversaries of the game,
+// the supporting adversary is set up before the leading adversary.
+func (g *Game) adversaries() []playedAdversary {
+	played := make([]playedAdversary, 0, 2)
+	for _, adv := range []struct {
+		name  Adversary
+		level int
+	}{
+		{g.SupportingAdversary, g.SupportingAdversaryLevel},
+		{g.LeadingAdversary, g.LeadingAdversaryLevel},
+	} {
+		if def, ok := LookupAdversary(adv.name); ok {
+			played = append(played, playedAdversary{def, adv.level})
+		}
+	}
+
+	return played
+}
+
+// hasRule is true when either adversary is played at a level with the rule.
+func (g *Game) hasRule(rule AdversaryRule) bool {
+	for _, adv := range g.adversaries() {
+		if lvl, ok := adv.Rules[rule]; ok && adv.level >= lvl {
+			return true
+		}
+	}
+
+	return false
+}
+
+var builtinAdversaries = []AdversaryDefinition{
+	{
+		Name:       BrandenburgPrussia,
+		MaxLevel:   6,
+		Difficulty: []int{1, 2, 4, 6, 7, 9, 10},
+		Escalation: "Land Rush: " +
+			"On each board with Town/City, add 1 Town to a land without Town.",
+		DeckRules: []DeckRule{
+			{
+				2,
+				"Put 1 of the Stage III cards between Stage I and Stage II.",
+				[]DeckOp{
+					MoveBefore(NthCard(3, -1), NthCard(2, 1)),
+					MarkSpeciallyPlaced(Above(NthCard(2, 1))),
+				},
+			},
+			{3, "Remove an additional Stage I card.", []DeckOp{
+				RemoveNth(1, 1),
+			}},
+			{4, "Remove an additional Stage II card.", []DeckOp{
+				RemoveNth(2, 1),
+			}},
+			{5, "Remove an additional Stage I card.", []DeckOp{
+				RemoveNth(1, 1),
+			}},
+			{6, "Remove all Stage I cards.", []DeckOp{
+				RemoveAll(1),
+			}},
+		},
+	},
+	{
+		Name:       England,
+		MaxLevel:   6,
+		Difficulty: []int{1, 3, 4, 6, 7, 9, 11},
+		Escalation: "Building Boom: " +
+			"On each board with Town/City, " +
+			"Build in the land with the most Town/City.",
+		Rules: map[AdversaryRule]int{
+			HighImmigrationRule:          3,
+			PermanentHighImmigrationRule: 4,
+		},
+	},
+	{
+		Name:       France,
+		MaxLevel:   6,
+		Difficulty: []int{2, 3, 5, 7, 8, 9, 10},
+		Escalation: "Demand for New Cash Crops: " +
+			"After Exploring, on each board, " +
+			"pick a land of the shown terrain. " +
+			"If it has Town/City, add 1 Blight. Otherwise, add 1 Town.",
+	},
+	{
+		Name:       HabsburgLivestock,
+		MaxLevel:   6,
+		Difficulty: []int{2, 3, 5, 6, 8, 9, 10},
+		Escalation: "Seek Prime Territory: " +
+			"On each board with 4 or fewer Blight, " +
+			"add 1 Town to a land without Town/Blight. " +
+			"On each board with 2 or fewer Blight, do so again.",
+		DeckRules: []DeckRule{
+			{3, "Remove 1 additional Stage I card.", []DeckOp{
+				RemoveNth(1, 1),
+			}},
+		},
+	},
+	{
+		Name:       HabsburgMines,
+		MaxLevel:   6,
+		Difficulty: []int{1, 3, 4, 5, 7, 9, 10},
+		Escalation: "Mining Tunnels: " +
+			"After Advancing Invader Cards, on each board, " +
+			"Explore in 2 lands " +
+			"whose terrains don't match a Ravage or Build card.",
+		DeckRules: []DeckRule{
+			{
+				4,
+				"Place the Salt Deposits card " +
+					"in place of the 2nd Stage II card.",
+				[]DeckOp{
+					ReplaceNth(2, 2, StageTwoSaltDeposits),
+					MarkSpeciallyPlaced(NthCard(2, 2)),
+				},
+			},
+		},
+		Reveals: []Reveal{
+			// Coastal Lands is removed from the game.
+			{4, StageTwoCoastal},
+		},
+	},
+	{
+		Name:       Russia,
+		MaxLevel:   6,
+		Difficulty: []int{1, 3, 4, 6, 7, 9, 11},
+		Escalation: "Stalk the Predators: " +
+			"Add 2 Explorers per board to lands with Beasts. " +
+			"If you can't, instead add 2 Explorers per board " +
+			"to lands matching a Ravage card.",
+		DeckRules: []DeckRule{
+			{4, "Put 1 Stage III card after each Stage II card.", []DeckOp{
+				MoveAfterEach(NthCard(3, -1), 2),
+			}},
+		},
+		Rules: map[AdversaryRule]int{
+			EntrenchedRule: 5,
+		},
+	},
+	{
+		Name:       Scotland,
+		MaxLevel:   6,
+		Difficulty: []int{1, 3, 4, 6, 7, 8, 10},
+		Escalation: "Ports Sprawl Outward: " +
+			"On the single board with the most Coastal Town/City, " +
+			"add 1 Town to the N lands with the fewest Town " +
+			"(N = number of players).",
+		DeckRules: []DeckRule{
+			{2, "Place Coastal Lands as the 3rd Stage II card.", []DeckOp{
+				ReplaceNth(2, 3, StageTwoCoastal),
+				MarkSpeciallyPlaced(NthCard(2, 3)),
+			}},
+			{
+				2,
+				"Move the two Stage II cards above Coastal Lands up by one.",
+				[]DeckOp{
+					MoveBefore(NthCard(2, 1), Above(NthCard(2, 1))),
+					MarkSpeciallyPlaced(NthCard(2, 1)),
+					MoveBefore(NthCard(2, 2), Above(NthCard(2, 2))),
+					MarkSpeciallyPlaced(NthCard(2, 2)),
+				},
+			},
+			{
+				4,
+				"Replace the bottom Stage I card " +
+					"with the bottom Stage III card.",
+				[]DeckOp{
+					MoveBefore(NthCard(3, -1), NthCard(1, -1)),
+					MarkSpeciallyPlaced(Above(NthCard(1, -1))),
+					RemoveNth(1, -1),
+				},
+			},
+		},
+		Reveals: []Reveal{
+			// Coastal Lands is placed in the deck.
+			{2, StageTwoCoastal},
+		},
+	},
+	{
+		Name:       Sweden,
+		MaxLevel:   6,
+		Difficulty: []int{1, 2, 3, 5, 6, 7, 8},
+		Escalation: "Swayed by the Invaders: " +
+			"After Invaders Explore into each land this phase, " +
+			"if that land has at least as many Invaders as Dahan, " +
+			"replace 1 Dahan with 1 Town.",
+	},
+}
@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestRegisterAdversary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Builtin", func(t *testing.T) {
+		t.Parallel()
+
+		names := domain.AdversaryNames()
+		for _, a := range []domain.Adversary{
+			domain.BrandenburgPrussia,
+			domain.England,
+			domain.France,
+			domain.HabsburgLivestock,
+			domain.HabsburgMines,
+			domain.Russia,
+			domain.Scotland,
+			domain.Sweden,
+		} {
+			def, ok := domain.LookupAdversary(a)
+			assert.Assert(t, ok, a)
+			assert.Equal(t, 6, def.MaxLevel)
+			found := false
+			for _, n := range names {
+				found = found || n == a
+			}
+			assert.Assert(t, found, a)
+		}
+	})
+
+	t.Run("Homebrew", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NilError(t, domain.RegisterAdversary(domain.AdversaryDefinition{
+			Name:     "homebrew-registered",
+			MaxLevel: 3,
+			DeckRules: []domain.DeckRule{
+				{1, "Remove all Stage II cards.", []domain.DeckOp{
+					domain.RemoveAll(2),
+				}},
+				{3, "Put Coastal Lands on top.", []domain.DeckOp{
+					domain.InsertAt(domain.StageTwoCoastal, 1, 1),
+					domain.MarkSpeciallyPlaced(domain.NthCard(2, 1)),
+				}},
+			},
+			Reveals: []domain.Reveal{{3, domain.StageTwoCoastal}},
+			Rules: map[domain.AdversaryRule]int{
+				domain.EntrenchedRule: 2,
+			},
+		}))
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      "homebrew-registered",
+			LeadingAdversaryLevel: 3,
+		})
+		assert.Equal(t, "| 2C* 1? 1? 1? 3? 3? 3? 3? 3?", deckString(game))
+		assert.Assert(t, game.InvaderCardpool().Revealed[2].Contains(
+			domain.StageTwoCoastal,
+		))
+		assert.NilError(t, game.Entrenched(domain.StageThreeJungleSands))
+
+		game = initGame(t, &domain.Game{
+			LeadingAdversary:         domain.France,
+			SupportingAdversary:      "homebrew-registered",
+			SupportingAdversaryLevel: 1,
+		})
+		assert.Equal(t, "| 1? 1? 1? 3? 3? 3? 3? 3?", deckString(game))
+		assert.ErrorIs(
+			t,
+			game.Entrenched(domain.StageThreeJungleSands),
+			domain.ErrNotEntrenched,
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, def := range []domain.AdversaryDefinition{
+			{MaxLevel: 6},
+			{Name: "homebrew-levels", MinLevel: 2, MaxLevel: 1},
+			{Name: "homebrew-reveals", MaxLevel: 6, Reveals: []domain.Reveal{
+				{1, domain.StageTwoSaltDeposits},
+			}},
+		} {
+			err := domain.RegisterAdversary(def)
+			assert.ErrorIs(t, err, domain.ErrInvalidAdversary)
+			_, ok := domain.LookupAdversary(def.Name)
+			assert.Assert(t, !ok)
+		}
+	})
+}
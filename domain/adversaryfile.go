@@ -0,0 +1,558 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// AdversaryFileError occurs when an adversary data file is invalid.
+// Line is 0 when the problem isn't on any single line of the file.
+type AdversaryFileError struct {
+	Line int
+	Err  error
+}
+
+func (err AdversaryFileError) Error() string {
+	if err.Line == 0 {
+		return err.Err.Error()
+	}
+
+	return fmt.Sprintf("line %d: %v", err.Line, err.Err)
+}
+
+// Is makes AdversaryFileError an ErrInvalidAdversary.
+func (err AdversaryFileError) Is(target error) bool {
+	return target == ErrInvalidAdversary
+}
+
+func (err AdversaryFileError) Unwrap() error {
+	return err.Err
+}
+
+// adversaryFile is an AdversaryDefinition in a TOML or JSON data file.
+//
+//	name = "homebrew"
+//	maxLevel = 2
+//	difficulty = [1, 2, 4]
+//	escalation = "Add 1 Explorer to each Coastal land."
+//
+//	[rules]
+//	entrenched = 2
+//
+//	[[reveals]]
+//	level = 1
+//	card = "2C"
+//
+//	[[deck]]
+//	level = 1
+//	text = "Place Coastal Lands as the 1st Stage II card."
+//	ops = ["replace-nth 2 1 2C", "mark-specially-placed 2:1"]
+type adversaryFile struct {
+	Name       Adversary             `json:"name"       toml:"name"`
+	MinLevel   int                   `json:"minLevel"   toml:"minLevel"`
+	MaxLevel   int                   `json:"maxLevel"   toml:"maxLevel"`
+	Difficulty []int                 `json:"difficulty" toml:"difficulty"`
+	Escalation string                `json:"escalation" toml:"escalation"`
+	Rules      map[string]int        `json:"rules"      toml:"rules"`
+	Reveals    []adversaryFileReveal `json:"reveals"    toml:"reveals"`
+	Deck       []adversaryFileRule   `json:"deck"       toml:"deck"`
+}
+
+type adversaryFileReveal struct {
+	Level int    `json:"level" toml:"level"`
+	Card  string `json:"card"  toml:"card"`
+}
+
+type adversaryFileRule struct {
+	Level int      `json:"level" toml:"level"`
+	Text  string   `json:"text"  toml:"text"`
+	Ops   []string `json:"ops"   toml:"ops"`
+}
+
+// UnmarshalAdversaryJSON reads an adversary definition from a JSON data file.
+func UnmarshalAdversaryJSON(data []byte) (AdversaryDefinition, error) {
+	var file adversaryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		var syntax *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntax):
+			return AdversaryDefinition{}, AdversaryFileError{
+				lineAt(data, syntax.Offset),
+				err,
+			}
+		case errors.As(err, &typ):
+			return AdversaryDefinition{}, AdversaryFileError{
+				lineAt(data, typ.Offset),
+				err,
+			}
+		default:
+			return AdversaryDefinition{}, AdversaryFileError{0, err}
+		}
+	}
+
+	return file.definition(jsonLines(data))
+}
+
+// UnmarshalAdversaryTOML reads an adversary definition from a TOML data file.
+func UnmarshalAdversaryTOML(data []byte) (AdversaryDefinition, error) {
+	var file adversaryFile
+	if err := toml.Unmarshal(data, &file); err != nil {
+		var decode *toml.DecodeError
+		if errors.As(err, &decode) {
+			row, _ := decode.Position()
+
+			return AdversaryDefinition{}, AdversaryFileError{row, err}
+		}
+
+		return AdversaryDefinition{}, AdversaryFileError{0, err}
+	}
+
+	return file.definition(tomlLines(data))
+}
+
+// definition validates the file, lines are where each key path
+// (e.g. deck.0.ops.1) is in the file.
+func (file adversaryFile) definition(
+	lines map[string]int,
+) (AdversaryDefinition, error) {
+	at := func(path string, format string, args ...any) error {
+		return AdversaryFileError{lines[path], fmt.Errorf(format, args...)}
+	}
+
+	if err := checkAdversaryKeys(lines); err != nil {
+		return AdversaryDefinition{}, err
+	}
+	if file.Name == UnknownAdversary {
+		return AdversaryDefinition{}, at("name", "the adversary has no name")
+	}
+	if file.MinLevel < 0 {
+		return AdversaryDefinition{}, at(
+			"minLevel",
+			"the minimum level %d is negative",
+			file.MinLevel,
+		)
+	}
+	if file.MaxLevel < file.MinLevel {
+		return AdversaryDefinition{}, at(
+			"maxLevel",
+			"the maximum level %d is below the minimum level %d",
+			file.MaxLevel,
+			file.MinLevel,
+		)
+	}
+	level := func(path string, lvl int) error {
+		if lvl < file.MinLevel || lvl > file.MaxLevel {
+			return at(
+				path,
+				"level %d is not between %d and %d",
+				lvl,
+				file.MinLevel,
+				file.MaxLevel,
+			)
+		}
+
+		return nil
+	}
+
+	def := AdversaryDefinition{
+		Name:       file.Name,
+		MinLevel:   file.MinLevel,
+		MaxLevel:   file.MaxLevel,
+		Difficulty: file.Difficulty,
+		Escalation: file.Escalation,
+		DeckRules:  make([]DeckRule, 0, len(file.Deck)),
+		Reveals:    make([]Reveal, 0, len(file.Reveals)),
+		Rules:      make(map[AdversaryRule]int, len(file.Rules)),
+	}
+	if len(def.Difficulty) != 0 && len(def.Difficulty) != def.MaxLevel+1 {
+		return AdversaryDefinition{}, at(
+			"difficulty",
+			"expected a difficulty for each level 0 to %d, not %d",
+			def.MaxLevel,
+			len(def.Difficulty),
+		)
+	}
+
+	// Map iteration is random so the rules are sorted to report the same error.
+	rules := make([]string, 0, len(file.Rules))
+	for r := range file.Rules {
+		rules = append(rules, r)
+	}
+	sort.Strings(rules)
+	for _, r := range rules {
+		path := "rules." + r
+		switch rule := AdversaryRule(r); rule {
+		case HighImmigrationRule, PermanentHighImmigrationRule, EntrenchedRule:
+			if err := level(path, file.Rules[r]); err != nil {
+				return AdversaryDefinition{}, err
+			}
+			def.Rules[rule] = file.Rules[r]
+		default:
+			return AdversaryDefinition{}, at(path, "unknown rule %q", r)
+		}
+	}
+
+	for i, r := range file.Reveals {
+		path := "reveals." + strconv.Itoa(i)
+		if err := level(path+".level", r.Level); err != nil {
+			return AdversaryDefinition{}, err
+		}
+		card, err := ParseInvaderCard(r.Card)
+		if err != nil {
+			return AdversaryDefinition{}, at(path+".card", "%w", err)
+		}
+		if !isStandard(card) {
+			return AdversaryDefinition{}, at(
+				path+".card",
+				"%w",
+				UnknownCardError{card},
+			)
+		}
+		def.Reveals = append(def.Reveals, Reveal{r.Level, card})
+	}
+
+	for i, r := range file.Deck {
+		path := "deck." + strconv.Itoa(i)
+		if err := level(path+".level", r.Level); err != nil {
+			return AdversaryDefinition{}, err
+		}
+		if len(r.Ops) == 0 {
+			return AdversaryDefinition{}, at(path, "the rule has no ops")
+		}
+		rule := DeckRule{r.Level, r.Text, make([]DeckOp, 0, len(r.Ops))}
+		for j, o := range r.Ops {
+			op, err := ParseDeckOp(o)
+			if err != nil {
+				return AdversaryDefinition{}, at(
+					path+".ops."+strconv.Itoa(j),
+					"%w",
+					err,
+				)
+			}
+			rule.Ops = append(rule.Ops, op)
+		}
+		def.DeckRules = append(def.DeckRules, rule)
+	}
+
+	return def, nil
+}
+
+// checkAdversaryKeys finds the first key which isn't part of the file format.
+func checkAdversaryKeys(lines map[string]int) error {
+	unknown := ""
+	for path := range lines {
+		if knownAdversaryKey(strings.Split(path, ".")) {
+			continue
+		}
+		if unknown == "" || lines[path] < lines[unknown] ||
+			(lines[path] == lines[unknown] && path < unknown) {
+			unknown = path
+		}
+	}
+	if unknown == "" {
+		return nil
+	}
+
+	return AdversaryFileError{
+		lines[unknown],
+		fmt.Errorf("unknown key %q", unknown),
+	}
+}
+
+func knownAdversaryKey(keys []string) bool {
+	index := func(k string) bool {
+		_, err := strconv.Atoi(k)
+
+		return err == nil
+	}
+
+	switch keys[0] {
+	case "name", "minLevel", "maxLevel", "escalation":
+		return len(keys) == 1
+	case "difficulty":
+		return len(keys) == 1 || (len(keys) == 2 && index(keys[1]))
+	case "rules":
+		return len(keys) <= 2
+	case "reveals":
+		return len(keys) <= 2 && (len(keys) == 1 || index(keys[1])) ||
+			len(keys) == 3 && index(keys[1]) &&
+				(keys[2] == "level" || keys[2] == "card")
+	case "deck":
+		return len(keys) <= 2 && (len(keys) == 1 || index(keys[1])) ||
+			len(keys) == 3 && index(keys[1]) &&
+				(keys[2] == "level" || keys[2] == "text" || keys[2] == "ops") ||
+			len(keys) == 4 && index(keys[1]) &&
+				keys[2] == "ops" && index(keys[3])
+	default:
+		return false
+	}
+}
+
+// ParseDeckOp parses an operation written like the function creating it,
+// e.g. "remove-nth 1 1" or "move-before 3:-1 2:1".
+// Cards are selected as STAGE:N (see NthCard), above(...), or below(...).
+func ParseDeckOp(text string) (DeckOp, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return nil, errors.New("the operation is empty")
+	}
+
+	syntax, ok := deckOpSyntax[fields[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation %q", fields[0])
+	}
+	if len(fields)-1 != len(syntax.args) {
+		return nil, fmt.Errorf(
+			"expected %s %s",
+			fields[0],
+			strings.Join(syntax.args, " "),
+		)
+	}
+
+	args := make([]any, 0, len(syntax.args))
+	for i, a := range syntax.args {
+		var arg any
+		var err error
+		switch a {
+		case "STAGE":
+			arg, err = parseStage(fields[i+1])
+		case "N":
+			arg, err = parseNth(fields[i+1])
+		case "CARD":
+			arg, err = ParseInvaderCard(fields[i+1])
+		case "SELECTOR":
+			arg, err = parseCardSelector(fields[i+1])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fields[0], err)
+		}
+		args = append(args, arg)
+	}
+
+	return syntax.op(args), nil
+}
+
+//nolint:forcetypeassert // the args are parsed in the order of the syntax
+var deckOpSyntax = map[string]struct {
+	args []string
+	op   func(args []any) DeckOp
+}{
+	"remove-nth": {[]string{"STAGE", "N"}, func(args []any) DeckOp {
+		return RemoveNth(args[0].(int), args[1].(int))
+	}},
+	"remove-all": {[]string{"STAGE"}, func(args []any) DeckOp {
+		return RemoveAll(args[0].(int))
+	}},
+	"insert-at": {[]string{"CARD", "STAGE", "N"}, func(args []any) DeckOp {
+		return InsertAt(args[0].(InvaderCard), args[1].(int), args[2].(int))
+	}},
+	"replace-nth": {[]string{"STAGE", "N", "CARD"}, func(args []any) DeckOp {
+		return ReplaceNth(args[0].(int), args[1].(int), args[2].(InvaderCard))
+	}},
+	"move-before": {[]string{"SELECTOR", "SELECTOR"}, func(args []any) DeckOp {
+		return MoveBefore(args[0].(CardSelector), args[1].(CardSelector))
+	}},
+	"move-after": {[]string{"SELECTOR", "SELECTOR"}, func(args []any) DeckOp {
+		return MoveAfter(args[0].(CardSelector), args[1].(CardSelector))
+	}},
+	"move-after-each": {[]string{"SELECTOR", "STAGE"}, func(args []any) DeckOp {
+		return MoveAfterEach(args[0].(CardSelector), args[1].(int))
+	}},
+	"mark-specially-placed": {[]string{"SELECTOR"}, func(args []any) DeckOp {
+		return MarkSpeciallyPlaced(args[0].(CardSelector))
+	}},
+}
+
+func parseStage(s string) (int, error) {
+	stg, err := strconv.Atoi(s)
+	if err != nil || stg < 1 || stg > 3 {
+		return 0, fmt.Errorf("%q is not a stage", s)
+	}
+
+	return stg, nil
+}
+
+func parseNth(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("%q is not a card number", s)
+	}
+
+	return n, nil
+}
+
+func parseCardSelector(s string) (CardSelector, error) {
+	for _, rel := range []struct {
+		name string
+		sel  func(CardSelector) CardSelector
+	}{
+		{"above", Above},
+		{"below", Below},
+	} {
+		if inner, ok := strings.CutPrefix(s, rel.name+"("); ok {
+			inner, ok = strings.CutSuffix(inner, ")")
+			if !ok {
+				return nil, fmt.Errorf("%q is missing a )", s)
+			}
+			sel, err := parseCardSelector(inner)
+			if err != nil {
+				return nil, err
+			}
+
+			return rel.sel(sel), nil
+		}
+	}
+
+	stage, nth, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, fmt.Errorf("%q is not a card selector", s)
+	}
+	stg, err := parseStage(stage)
+	if err != nil {
+		return nil, err
+	}
+	n, err := parseNth(nth)
+	if err != nil {
+		return nil, err
+	}
+
+	return NthCard(stg, n), nil
+}
+
+// lineAt is the line of the offset in the data, starting at 1.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	return bytes.Count(data[:offset], []byte{'\n'}) + 1
+}
+
+// jsonLines is the line of every value in valid json keyed by its path.
+func jsonLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(path string)
+	walk = func(path string) {
+		// The offset is the end of the previous token.
+		start := dec.InputOffset()
+		for start < int64(len(data)) &&
+			strings.ContainsRune(" \t\r\n:,", rune(data[start])) {
+			start++
+		}
+		tok, err := dec.Token()
+		if err != nil {
+			return
+		}
+		if path != "" {
+			lines[path] = lineAt(data, start)
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return
+				}
+				k, _ := key.(string)
+				walk(joinPath(path, k))
+			}
+			_, _ = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				walk(joinPath(path, strconv.Itoa(i)))
+			}
+			_, _ = dec.Token()
+		}
+	}
+	walk("")
+
+	return lines
+}
+
+// tomlLines is the line of every key in valid toml keyed by its path.
+// Array elements are on the line of their key unless they are strings.
+func tomlLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	tables := make(map[string]int)
+	parser := unstable.Parser{}
+	parser.Reset(data)
+
+	key := func(it unstable.Iterator) (string, int) {
+		keys := []string{}
+		line := 0
+		for it.Next() {
+			if line == 0 {
+				line = parser.Shape(it.Node().Raw).Start.Line
+			}
+			keys = append(keys, string(it.Node().Data))
+		}
+
+		return strings.Join(keys, "."), line
+	}
+
+	var value func(path string, line int, node *unstable.Node)
+	value = func(path string, line int, node *unstable.Node) {
+		lines[path] = line
+		switch node.Kind {
+		case unstable.Array:
+			it := node.Children()
+			for i := 0; it.Next(); i++ {
+				elem := it.Node()
+				eline := line
+				if elem.Kind == unstable.String {
+					eline = parser.Shape(elem.Raw).Start.Line
+				}
+				value(joinPath(path, strconv.Itoa(i)), eline, elem)
+			}
+		case unstable.InlineTable:
+			it := node.Children()
+			for it.Next() {
+				k, kline := key(it.Node().Key())
+				value(joinPath(path, k), kline, it.Node().Value())
+			}
+		}
+	}
+
+	table := ""
+	for parser.NextExpression() {
+		expr := parser.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			k, line := key(expr.Key())
+			table = k
+			if expr.Kind == unstable.ArrayTable {
+				table = joinPath(k, strconv.Itoa(tables[k]))
+				tables[k]++
+				if _, ok := lines[k]; !ok {
+					lines[k] = line
+				}
+			}
+			lines[table] = line
+		case unstable.KeyValue:
+			k, line := key(expr.Key())
+			value(joinPath(table, k), line, expr.Value())
+		}
+	}
+
+	return lines
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,281 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+const homebrewTOML = `name = "homebrew-toml"
+maxLevel = 2
+difficulty = [1, 3, 4]
+escalation = "Add 1 Explorer to each Coastal land."
+
+[rules]
+entrenched = 2
+
+[[reveals]]
+level = 1
+card = "2C"
+
+[[deck]]
+level = 1
+text = "Place Coastal Lands as the 1st Stage II card."
+ops = [
+  "replace-nth 2 1 2C",
+  "mark-specially-placed 2:1",
+]
+
+[[deck]]
+level = 2
+text = "Put 1 Stage III card after each Stage II card."
+ops = ["move-after-each 3:-1 2"]
+`
+
+const homebrewJSON = `{
+  "name": "homebrew-json",
+  "maxLevel": 2,
+  "difficulty": [1, 3, 4],
+  "escalation": "Add 1 Explorer to each Coastal land.",
+  "rules": {"entrenched": 2},
+  "reveals": [{"level": 1, "card": "2C"}],
+  "deck": [
+    {
+      "level": 1,
+      "text": "Place Coastal Lands as the 1st Stage II card.",
+      "ops": [
+        "replace-nth 2 1 2C",
+        "mark-specially-placed 2:1"
+      ]
+    },
+    {
+      "level": 2,
+      "text": "Put 1 Stage III card after each Stage II card.",
+      "ops": ["move-after-each 3:-1 2"]
+    }
+  ]
+}`
+
+//nolint:exhaustruct
+func TestUnmarshalAdversary(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		unmarshal func([]byte) (domain.AdversaryDefinition, error)
+		data      string
+	}{
+		{"TOML", domain.UnmarshalAdversaryTOML, homebrewTOML},
+		{"JSON", domain.UnmarshalAdversaryJSON, homebrewJSON},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			def, err := tc.unmarshal([]byte(tc.data))
+			assert.NilError(t, err)
+			assert.NilError(t, domain.RegisterAdversary(def))
+			assert.DeepEqual(t, []int{1, 3, 4}, def.Difficulty)
+			assert.Equal(
+				t,
+				"Add 1 Explorer to each Coastal land.",
+				def.Escalation,
+			)
+
+			game := initGame(t, &domain.Game{
+				LeadingAdversary:      def.Name,
+				LeadingAdversaryLevel: 2,
+			})
+			assert.Equal(
+				t,
+				"| 1? 1? 1? 2C* 3?* 2? 3?* 2? 3?* 2? 3?* 3?",
+				deckString(game),
+			)
+			assert.Assert(t, game.InvaderCardpool().Revealed[2].Contains(
+				domain.StageTwoCoastal,
+			))
+			assert.NilError(t, game.Entrenched(domain.StageTwoJungle))
+		})
+	}
+}
+
+//nolint:exhaustruct
+func TestUnmarshalAdversary_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		toml     bool
+		data     string
+		expected string
+	}{
+		{"TOMLSyntax", true, "name = \"x\"\nmaxLevel = \n", "line 2: "},
+		{"TOMLType", true, "name = \"x\"\n\nmaxLevel = \"six\"\n", "line 3: "},
+		{
+			"TOMLUnknownKey",
+			true,
+			"name = \"x\"\n" +
+				"maxLevl = 6\n",
+			`line 2: unknown key "maxLevl"`,
+		},
+		{"TOMLNoName", true, "maxLevel = 6\n", "the adversary has no name"},
+		{
+			"TOMLLevels",
+			true,
+			"name = \"x\"\n" +
+				"minLevel = 2\n" +
+				"maxLevel = 1\n",
+			"line 3: the maximum level 1 is below the minimum level 2",
+		},
+		{
+			"TOMLDifficulty",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"difficulty = [1]\n",
+			"line 3: expected a difficulty for each level 0 to 1, not 1",
+		},
+		{
+			"TOMLRule",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"[rules]\n" +
+				"fear = 1\n",
+			`line 4: unknown rule "fear"`,
+		},
+		{
+			"TOMLRuleLevel",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"rules = { entrenched = 3 }\n",
+			"line 3: level 3 is not between 0 and 1",
+		},
+		{
+			"TOMLRevealCard",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"[[reveals]]\n" +
+				"level = 1\n" +
+				"card = \"2SALT\"\n",
+			"line 5: 2SALT is not an invader card",
+		},
+		{
+			"TOMLDeckLevel",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"[[deck]]\n" +
+				"level = 1\n" +
+				"ops = [\"remove-all 1\"]\n" +
+				"[[deck]]\n" +
+				"level = 2\n" +
+				"ops = [\"remove-all 1\"]\n",
+			"line 7: level 2 is not between 0 and 1",
+		},
+		{
+			"TOMLNoOps",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"\n" +
+				"[[deck]]\n" +
+				"level = 1\n",
+			"line 4: the rule has no ops",
+		},
+		{
+			"TOMLOp",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"[[deck]]\n" +
+				"level = 1\n" +
+				"ops = [\n" +
+				"  \"remove-all 1\",\n" +
+				"  \"remove 1\",\n" +
+				"]\n",
+			`line 7: unknown operation "remove"`,
+		},
+		{
+			"TOMLOpArgs",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"[[deck]]\n" +
+				"level = 1\n" +
+				"ops = [\"remove-nth 1\"]\n",
+			"line 5: expected remove-nth STAGE N",
+		},
+		{
+			"TOMLSelector",
+			true,
+			"name = \"x\"\n" +
+				"maxLevel = 1\n" +
+				"[[deck]]\n" +
+				"level = 1\n" +
+				"ops = [\"move-before above(3:-1 2:1\"]\n",
+			`line 5: move-before: "above(3:-1" is missing a )`,
+		},
+		{
+			"JSONSyntax",
+			false,
+			"{\n" +
+				"  \"name\": \"x\",\n" +
+				"  \"maxLevel\": ,\n" +
+				"}",
+			"line 3: ",
+		},
+		{
+			"JSONType",
+			false,
+			"{\n" +
+				"  \"name\": \"x\",\n" +
+				"  \"maxLevel\": \"six\"\n" +
+				"}",
+			"line 3: ",
+		},
+		{
+			"JSONUnknownKey",
+			false,
+			"{\n" +
+				"  \"name\": \"x\",\n" +
+				"  \"deck\": [{\"lvl\": 1}]\n" +
+				"}",
+			`line 3: unknown key "deck.0.lvl"`,
+		},
+		{
+			"JSONOp",
+			false,
+			"{\n" +
+				"  \"name\": \"x\",\n" +
+				"  \"maxLevel\": 1,\n" +
+				"  \"deck\": [{\n" +
+				"    \"level\": 1,\n" +
+				"    \"ops\": [\n" +
+				"      \"remove-all 1\",\n" +
+				"      \"remove-all 4\"\n" +
+				"    ]\n" +
+				"  }]\n" +
+				"}",
+			`line 8: remove-all: "4" is not a stage`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			unmarshal := domain.UnmarshalAdversaryJSON
+			if tc.toml {
+				unmarshal = domain.UnmarshalAdversaryTOML
+			}
+			_, err := unmarshal([]byte(tc.data))
+			assert.ErrorIs(t, err, domain.ErrInvalidAdversary)
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
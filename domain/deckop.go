@@ -0,0 +1,207 @@
+package domain
+
+// DeckOp is a single change to the cards in the invader deck.
+// Operations never change the deck they are given.
+type DeckOp func(deck []InvaderCardInDeck) []InvaderCardInDeck
+
+// DeckRule is a single change an adversary makes to the invader deck
+// when played at or above the level.
+type DeckRule struct {
+	Level int
+	// Text is the rule as written on the adversary's card.
+	Text string
+	Ops  []DeckOp
+}
+
+// Apply runs each of the operations of the rule in order.
+func (rule DeckRule) Apply(deck []InvaderCardInDeck) []InvaderCardInDeck {
+	for _, op := range rule.Ops {
+		deck = op(deck)
+	}
+
+	return deck
+}
+
+// CardSelector finds the index of a card in the invader deck,
+// it is -1 when there isn't a matching card.
+type CardSelector func(deck []InvaderCardInDeck) int
+
+// NthCard selects the nth card of the stage counting from 1 at the top
+// of the deck, negative n counts from -1 at the bottom of the deck.
+func NthCard(stage int, n int) CardSelector {
+	return func(deck []InvaderCardInDeck) int {
+		seen := 0
+		for i := range deck {
+			cix := i
+			if n < 0 {
+				cix = len(deck) - 1 - i
+			}
+			if deck[cix].Stage != stage {
+				continue
+			}
+
+			seen++
+			if seen == n || -seen == n {
+				return cix
+			}
+		}
+
+		return -1
+	}
+}
+
+// Above selects the card directly above the selected card.
+func Above(sel CardSelector) CardSelector {
+	return func(deck []InvaderCardInDeck) int {
+		cix := sel(deck)
+		if cix < 1 {
+			return -1
+		}
+
+		return cix - 1
+	}
+}
+
+// Below selects the card directly below the selected card.
+func Below(sel CardSelector) CardSelector {
+	return func(deck []InvaderCardInDeck) int {
+		cix := sel(deck)
+		if cix < 0 || cix+1 >= len(deck) {
+			return -1
+		}
+
+		return cix + 1
+	}
+}
+
+// RemoveNth removes the nth card of the stage (see NthCard).
+func RemoveNth(stage int, n int) DeckOp {
+	return func(deck []InvaderCardInDeck) []InvaderCardInDeck {
+		cix := NthCard(stage, n)(deck)
+		if cix == -1 {
+			return deck
+		}
+
+		return splice(deck, cix, 1)
+	}
+}
+
+// RemoveAll removes every card of the stage.
+func RemoveAll(stage int) DeckOp {
+	return func(deck []InvaderCardInDeck) []InvaderCardInDeck {
+		mod := make([]InvaderCardInDeck, 0, len(deck))
+		for _, c := range deck {
+			if c.Stage != stage {
+				mod = append(mod, c)
+			}
+		}
+
+		return mod
+	}
+}
+
+// InsertAt puts the card above the nth card of the stage (see NthCard).
+func InsertAt(card InvaderCard, stage int, n int) DeckOp {
+	return func(deck []InvaderCardInDeck) []InvaderCardInDeck {
+		cix := NthCard(stage, n)(deck)
+		if cix == -1 {
+			return deck
+		}
+
+		return splice(deck, cix, 0, InvaderCardInDeck{card, false})
+	}
+}
+
+// ReplaceNth puts the card in place of the nth card of the stage (see NthCard).
+func ReplaceNth(stage int, n int, card InvaderCard) DeckOp {
+	return func(deck []InvaderCardInDeck) []InvaderCardInDeck {
+		cix := NthCard(stage, n)(deck)
+		if cix == -1 {
+			return deck
+		}
+
+		return splice(deck, cix, 1, InvaderCardInDeck{card, false})
+	}
+}
+
+// MoveBefore takes the card selected by from and puts it above
+// the card selected by to, both are selected before the card is moved.
+func MoveBefore(from CardSelector, to CardSelector) DeckOp {
+	return move(from, to, 0)
+}
+
+// MoveAfter takes the card selected by from and puts it below
+// the card selected by to, both are selected before the card is moved.
+func MoveAfter(from CardSelector, to CardSelector) DeckOp {
+	return move(from, to, 1)
+}
+
+// MoveAfterEach puts the card selected by from below each card of the stage
+// starting at the top of the deck, the moved cards are specially placed.
+func MoveAfterEach(from CardSelector, stage int) DeckOp {
+	return func(deck []InvaderCardInDeck) []InvaderCardInDeck {
+		n := 0
+		for _, c := range deck {
+			if c.Stage == stage {
+				n++
+			}
+		}
+
+		for i := 1; i <= n; i++ {
+			to := NthCard(stage, i)
+			if from(deck) == -1 || to(deck) == -1 {
+				continue
+			}
+			deck = MoveAfter(from, to)(deck)
+			deck = MarkSpeciallyPlaced(Below(to))(deck)
+		}
+
+		return deck
+	}
+}
+
+func move(from CardSelector, to CardSelector, offset int) DeckOp {
+	return func(deck []InvaderCardInDeck) []InvaderCardInDeck {
+		fix, tix := from(deck), to(deck)
+		if fix == -1 || tix == -1 || fix == tix {
+			return deck
+		}
+
+		card := deck[fix]
+		tix += offset
+		if fix < tix {
+			tix--
+		}
+
+		return splice(splice(deck, fix, 1), tix, 0, card)
+	}
+}
+
+// MarkSpeciallyPlaced marks the selected card as placed by an adversary.
+func MarkSpeciallyPlaced(sel CardSelector) DeckOp {
+	return func(deck []InvaderCardInDeck) []InvaderCardInDeck {
+		cix := sel(deck)
+		if cix == -1 {
+			return deck
+		}
+
+		mod := splice(deck, 0, 0)
+		mod[cix].SpeciallyPlaced = true
+
+		return mod
+	}
+}
+
+// splice copies the deck removing n cards at the index and adding the cards.
+func splice(
+	deck []InvaderCardInDeck,
+	cix int,
+	n int,
+	cards ...InvaderCardInDeck,
+) []InvaderCardInDeck {
+	mod := make([]InvaderCardInDeck, 0, len(deck)-n+len(cards))
+	mod = append(mod, deck[:cix]...)
+	mod = append(mod, cards...)
+
+	return append(mod, deck[cix+n:]...)
+}
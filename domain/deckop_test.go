@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestDeckOp(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		ops      []domain.DeckOp
+		expected string
+	}{
+		{"RemoveNth", []domain.DeckOp{
+			domain.RemoveNth(1, 1),
+			domain.RemoveNth(3, -1),
+		}, "| 1? 1? 2? 2? 2? 2? 3? 3? 3? 3?"},
+		{"RemoveNthMissing", []domain.DeckOp{
+			domain.RemoveNth(1, 4),
+		}, "| 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?"},
+		{"RemoveAll", []domain.DeckOp{
+			domain.RemoveAll(2),
+		}, "| 1? 1? 1? 3? 3? 3? 3? 3?"},
+		{"InsertAt", []domain.DeckOp{
+			domain.InsertAt(domain.StageTwoCoastal, 2, 2),
+		}, "| 1? 1? 1? 2? 2C 2? 2? 2? 3? 3? 3? 3? 3?"},
+		{"ReplaceNth", []domain.DeckOp{
+			domain.ReplaceNth(2, -1, domain.StageTwoSaltDeposits),
+		}, "| 1? 1? 1? 2? 2? 2? 2SALT 3? 3? 3? 3? 3?"},
+		{"MoveBefore", []domain.DeckOp{
+			domain.MoveBefore(domain.NthCard(3, 1), domain.NthCard(1, 1)),
+		}, "| 3? 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3?"},
+		{"MoveAfter", []domain.DeckOp{
+			domain.MoveAfter(domain.NthCard(1, 1), domain.NthCard(2, -1)),
+		}, "| 1? 1? 2? 2? 2? 2? 1? 3? 3? 3? 3? 3?"},
+		{"MoveAfterBelow", []domain.DeckOp{
+			domain.MoveAfter(domain.NthCard(2, 1), domain.NthCard(1, -1)),
+		}, "| 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?"},
+		{"MarkSpeciallyPlaced", []domain.DeckOp{
+			domain.MarkSpeciallyPlaced(domain.Above(domain.NthCard(2, 1))),
+			domain.MarkSpeciallyPlaced(domain.Below(domain.NthCard(3, -1))),
+		}, "| 1? 1? 1?* 2? 2? 2? 2? 3? 3? 3? 3? 3?"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			base := domain.NewInvaderDeck(&domain.Game{})
+			deck := domain.InvaderDeck{InDeck: base.InDeck}
+			for _, op := range tc.ops {
+				deck.InDeck = op(deck.InDeck)
+			}
+			assert.Equal(t, tc.expected, deck.String())
+			// The operations copy the deck instead of changing it.
+			assert.Equal(
+				t,
+				"| 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+				base.String(),
+			)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoDifficulty occurs when an adversary doesn't have difficulty data.
+var ErrNoDifficulty = errors.New("unknown difficulty")
+
+// Difficulty is how hard a game is.
+// A single adversary has an exact difficulty, two adversaries have a range.
+type Difficulty struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+func (d Difficulty) String() string {
+	if d.Min == d.Max {
+		return fmt.Sprintf("%g", d.Min)
+	}
+
+	return fmt.Sprintf("%g-%g", d.Min, d.Max)
+}
+
+// The share of the lower difficulty added when combining two adversaries.
+const (
+	minCombinedShare = 0.5
+	maxCombinedShare = 0.75
+)
+
+// Difficulty is the difficulty of the adversaries of the game.
+// When combining adversaries the official rules use the higher difficulty
+// plus 50-75% of the lower difficulty, regardless of which one leads.
+func (g *Game) Difficulty() (Difficulty, error) {
+	if err := g.Validate(); err != nil {
+		return Difficulty{}, err
+	}
+
+	diffs := make([]float64, 0, 2)
+	for _, adv := range g.adversaries() {
+		if len(adv.Difficulty) <= adv.level {
+			return Difficulty{}, fmt.Errorf(
+				"%w: %s has no difficulty for level %d",
+				ErrNoDifficulty,
+				adv.Name,
+				adv.level,
+			)
+		}
+		diffs = append(diffs, float64(adv.Difficulty[adv.level]))
+	}
+
+	switch len(diffs) {
+	case 0:
+		return Difficulty{}, nil
+	case 1:
+		return Difficulty{diffs[0], diffs[0]}, nil
+	}
+
+	higher, lower := diffs[0], diffs[1]
+	if lower > higher {
+		higher, lower = lower, higher
+	}
+
+	return Difficulty{
+		higher + lower*minCombinedShare,
+		higher + lower*maxCombinedShare,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestGame_Difficulty(t *testing.T) {
+	t.Parallel()
+
+	for n, tc := range map[string]struct {
+		game domain.Game
+		diff domain.Difficulty
+		str  string
+	}{
+		"NoAdversary": {domain.Game{}, domain.Difficulty{}, "0"},
+		"Leading": {
+			domain.Game{
+				LeadingAdversary:      domain.England,
+				LeadingAdversaryLevel: 6,
+			},
+			domain.Difficulty{Min: 11, Max: 11},
+			"11",
+		},
+		"Combined": {
+			domain.Game{
+				LeadingAdversary:         domain.BrandenburgPrussia,
+				LeadingAdversaryLevel:    2,
+				SupportingAdversary:      domain.Scotland,
+				SupportingAdversaryLevel: 3,
+			},
+			domain.Difficulty{Min: 8, Max: 9},
+			"8-9",
+		},
+		"HigherSupporting": {
+			domain.Game{
+				LeadingAdversary:         domain.Sweden,
+				LeadingAdversaryLevel:    1,
+				SupportingAdversary:      domain.France,
+				SupportingAdversaryLevel: 4,
+			},
+			domain.Difficulty{Min: 9, Max: 9.5},
+			"9-9.5",
+		},
+	} {
+		tc := tc
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			diff, err := tc.game.Difficulty()
+			assert.NilError(t, err)
+			assert.Equal(t, tc.diff, diff)
+			assert.Equal(t, tc.str, diff.String())
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&domain.Game{
+			LeadingAdversary:      domain.Sweden,
+			LeadingAdversaryLevel: 7,
+		}).Difficulty()
+		assert.ErrorIs(t, err, domain.ErrInvalidGame)
+	})
+
+	t.Run("NoDifficulty", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NilError(t, domain.RegisterAdversary(domain.AdversaryDefinition{
+			Name:     "homebrew-no-difficulty",
+			MaxLevel: 2,
+		}))
+		_, err := (&domain.Game{
+			LeadingAdversary: "homebrew-no-difficulty",
+		}).Difficulty()
+		assert.ErrorIs(t, err, domain.ErrNoDifficulty)
+	})
+}
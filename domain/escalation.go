@@ -0,0 +1,53 @@
+package domain
+
+// Escalation is when a drawn invader card triggers an adversary's
+// Escalation effect.
+type Escalation struct {
+	Adversary Adversary   `json:"adversary"`
+	Card      InvaderCard `json:"card"`
+	Text      string      `json:"text"`
+}
+
+// HasEscalationIcon is true for the cards which trigger the leading
+// adversary's Escalation, these are the Stage II cards.
+func (card InvaderCard) HasEscalationIcon() bool {
+	return card.Stage == 2
+}
+
+// escalations are the Escalations triggered by drawing the card.
+// The leading adversary escalates on cards with the escalation icon,
+// the supporting adversary escalates on Stage III cards instead.
+// Only the card matters, so a Stage III card drawn before any Stage II card
+// (e.g. for Brandenburg-Prussia) never triggers the leading adversary.
+func (g *Game) escalations(card InvaderCard) []Escalation {
+	escs := []Escalation{}
+	for _, adv := range []struct {
+		adversary Adversary
+		triggered bool
+	}{
+		{g.LeadingAdversary, card.HasEscalationIcon()},
+		{g.SupportingAdversary, card.Stage == 3},
+	} {
+		if !adv.triggered {
+			continue
+		}
+		if def, ok := LookupAdversary(adv.adversary); ok {
+			escs = append(escs, Escalation{def.Name, card, def.Escalation})
+		}
+	}
+
+	return escs
+}
+
+// Escalations are the Escalations triggered by the drawn cards, oldest first.
+// Undoing a draw also undoes its Escalations.
+func (g *InitializedGame) Escalations() []Escalation {
+	escs := []Escalation{}
+	for _, e := range g.events {
+		if drawn, ok := e.(CardDrawn); ok {
+			escs = append(escs, g.escalations(drawn.Card)...)
+		}
+	}
+
+	return escs
+}
@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestInitializedGame_Escalations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NoAdversary", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{})
+		for _, c := range []domain.InvaderCard{
+			domain.StageOneJungle,
+			domain.StageOneWetland,
+			domain.StageOneSands,
+			domain.StageTwoJungle,
+		} {
+			assert.NilError(t, game.Draw(c))
+		}
+		assert.DeepEqual(t, []domain.Escalation{}, game.Escalations())
+	})
+
+	t.Run("Leading", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      domain.England,
+			LeadingAdversaryLevel: 1,
+		})
+		for _, c := range []domain.InvaderCard{
+			domain.StageOneJungle,
+			domain.StageOneWetland,
+			domain.StageOneSands,
+			domain.StageTwoJungle,
+			domain.StageTwoCoastal,
+		} {
+			assert.NilError(t, game.Draw(c))
+		}
+
+		def, _ := domain.LookupAdversary(domain.England)
+		assert.DeepEqual(t, []domain.Escalation{
+			{domain.England, domain.StageTwoJungle, def.Escalation},
+			{domain.England, domain.StageTwoCoastal, def.Escalation},
+		}, game.Escalations())
+
+		assert.NilError(t, game.Undo())
+		assert.Equal(t, 1, len(game.Escalations()))
+		assert.NilError(t, game.Redo())
+		assert.Equal(t, 2, len(game.Escalations()))
+	})
+
+	t.Run("StageThreeFirst", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:         domain.BrandenburgPrussia,
+			LeadingAdversaryLevel:    2,
+			SupportingAdversary:      domain.Sweden,
+			SupportingAdversaryLevel: 1,
+		})
+		for _, c := range []domain.InvaderCard{
+			domain.StageOneJungle,
+			domain.StageOneWetland,
+			domain.StageOneSands,
+			domain.StageThreeJungleSands,
+		} {
+			assert.NilError(t, game.Draw(c))
+		}
+
+		sweden, _ := domain.LookupAdversary(domain.Sweden)
+		assert.DeepEqual(t, []domain.Escalation{
+			{domain.Sweden, domain.StageThreeJungleSands, sweden.Escalation},
+		}, game.Escalations())
+
+		assert.NilError(t, game.Draw(domain.StageTwoMountain))
+		prussia, _ := domain.LookupAdversary(domain.BrandenburgPrussia)
+		assert.DeepEqual(t, []domain.Escalation{
+			{domain.Sweden, domain.StageThreeJungleSands, sweden.Escalation},
+			{domain.BrandenburgPrussia, domain.StageTwoMountain, prussia.Escalation},
+		}, game.Escalations())
+	})
+}
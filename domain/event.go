@@ -0,0 +1,200 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNothingToUndo occurs when there are no events to undo.
+	ErrNothingToUndo = errors.New("there is nothing to undo")
+	// ErrNothingToRedo occurs when there are no undone events to redo.
+	ErrNothingToRedo = errors.New("there is nothing to redo")
+)
+
+// Event is a single change to the state containers of an InitializedGame.
+type Event interface {
+	apply(state gameState) error
+}
+
+// CardDrawn is when an invader card is drawn from the invader deck.
+type CardDrawn struct {
+	Card InvaderCard
+}
+
+func (e CardDrawn) apply(state gameState) error {
+	if err := state.checkRemoved(e.Card); err != nil {
+		return err
+	}
+	if err := state.invaderdeck.Draw(e.Card); err != nil {
+		return err
+	}
+	state.invadertrack.explore(e.Card)
+
+	return state.invadercardpool.reveal(e.Card)
+}
+
+// CardReturned is when a drawn invader card is returned to the invader deck.
+type CardReturned struct {
+	Card InvaderCard
+}
+
+func (e CardReturned) apply(state gameState) error {
+	var top InvaderCard
+	if len(state.invaderdeck.InDeck) != 0 {
+		top = state.invaderdeck.InDeck[0].InvaderCard
+	}
+	if err := state.invaderdeck.Return(e.Card); err != nil {
+		return err
+	}
+	// The top card of the deck is drawn in its place and discarded.
+	state.invadertrack.remove(e.Card)
+	state.invadertrack.discard(top)
+
+	return nil
+}
+
+// RisingInterestIgnored is when the top card of the invader deck is removed.
+type RisingInterestIgnored struct{}
+
+func (e RisingInterestIgnored) apply(state gameState) error {
+	state.invaderdeck.IgnoreRisingInterest()
+
+	return nil
+}
+
+// HardworkingSettlersDistracted is when a Stage II and III card are removed.
+type HardworkingSettlersDistracted struct{}
+
+func (e HardworkingSettlersDistracted) apply(state gameState) error {
+	state.invaderdeck.DistractHardworkingSettlers()
+
+	return nil
+}
+
+// CardEntrenched is when Russia adds an invader card to the discard.
+type CardEntrenched struct {
+	Card InvaderCard
+}
+
+func (e CardEntrenched) apply(state gameState) error {
+	if err := state.checkRemoved(e.Card); err != nil {
+		return err
+	}
+	if err := state.invaderdeck.Entrenched(e.Card); err != nil {
+		return err
+	}
+	state.invadertrack.discard(e.Card)
+
+	return state.invadercardpool.reveal(e.Card)
+}
+
+// InvadersAdvanced is when the cards on the invader track advance a space
+// at the end of the Invader Phase.
+type InvadersAdvanced struct{}
+
+func (e InvadersAdvanced) apply(state gameState) error {
+	state.invadertrack.Advance()
+
+	return nil
+}
+
+// Draw draws the card from the invader deck and reveals it.
+func (g *InitializedGame) Draw(card InvaderCard) error {
+	return g.record(CardDrawn{card})
+}
+
+// Return returns the card to the top of the invader deck.
+func (g *InitializedGame) Return(card InvaderCard) error {
+	return g.record(CardReturned{card})
+}
+
+// IgnoreRisingInterest removes the top card of the invader deck.
+func (g *InitializedGame) IgnoreRisingInterest() error {
+	return g.record(RisingInterestIgnored{})
+}
+
+// DistractHardworkingSettlers removes a Stage II and III card.
+func (g *InitializedGame) DistractHardworkingSettlers() error {
+	return g.record(HardworkingSettlersDistracted{})
+}
+
+// Entrenched adds the card to the discard and reveals it.
+func (g *InitializedGame) Entrenched(card InvaderCard) error {
+	return g.record(CardEntrenched{card})
+}
+
+// Advance moves the cards on the invader track at the end of the Invader Phase.
+func (g *InitializedGame) Advance() error {
+	return g.record(InvadersAdvanced{})
+}
+
+// Events are the events which have changed the game, oldest first.
+func (g *InitializedGame) Events() []Event {
+	events := make([]Event, len(g.events))
+	copy(events, g.events)
+
+	return events
+}
+
+// Undo rewinds the most recent event.
+func (g *InitializedGame) Undo() error {
+	if len(g.events) == 0 {
+		return ErrNothingToUndo
+	}
+
+	last := len(g.events) - 1
+	undone := g.events[last]
+	if err := g.replay(g.events[:last]); err != nil {
+		return err
+	}
+	g.undone = append(g.undone, undone)
+
+	return nil
+}
+
+// Redo re-applies the most recently undone event.
+func (g *InitializedGame) Redo() error {
+	if len(g.undone) == 0 {
+		return ErrNothingToRedo
+	}
+
+	last := len(g.undone) - 1
+	next := g.state().clone()
+	if err := g.undone[last].apply(next); err != nil {
+		return err
+	}
+
+	g.setState(next)
+	g.events = append(g.events, g.undone[last])
+	g.undone = g.undone[:last]
+
+	return nil
+}
+
+// record applies the event and appends it to the log.
+// The state containers are only changed if the event applies cleanly.
+func (g *InitializedGame) record(e Event) error {
+	next := g.state().clone()
+	if err := e.apply(next); err != nil {
+		return err
+	}
+
+	g.setState(next)
+	g.events = append(g.events, e)
+	g.undone = nil
+
+	return nil
+}
+
+// replay rebuilds the state containers from the base state and the events.
+func (g *InitializedGame) replay(events []Event) error {
+	next := g.base.clone()
+	for _, e := range events {
+		if err := e.apply(next); err != nil {
+			return err
+		}
+	}
+
+	g.setState(next)
+	g.events = events
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestInitializedGame_Events(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Log", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      domain.Russia,
+			LeadingAdversaryLevel: 5,
+		})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		assert.ErrorIs(
+			t,
+			game.Draw(domain.StageTwoJungle),
+			domain.ErrInvalidInvaderCard,
+		)
+		assert.NilError(t, game.IgnoreRisingInterest())
+		assert.NilError(t, game.Entrenched(domain.StageTwoWetland))
+		assert.NilError(t, game.Return(domain.StageTwoWetland))
+		assert.NilError(t, game.DistractHardworkingSettlers())
+		assert.Equal(
+			t,
+			"1J* 1?* | 2W 2? 3?* 2? 3?* 2? 3?* 3?*",
+			deckString(game),
+		)
+
+		assert.DeepEqual(t, []domain.Event{
+			domain.CardDrawn{domain.StageOneJungle},
+			domain.RisingInterestIgnored{},
+			domain.CardEntrenched{domain.StageTwoWetland},
+			domain.CardReturned{domain.StageTwoWetland},
+			domain.HardworkingSettlersDistracted{},
+		}, game.Events())
+	})
+
+	t.Run("Return", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		assert.NilError(t, game.Advance())
+		assert.NilError(t, game.Draw(domain.StageOneWetland))
+		assert.NilError(t, game.Return(domain.StageOneJungle))
+
+		assert.Equal(
+			t,
+			"1?* 1W* | 1J 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+			deckString(game),
+		)
+		track := game.InvaderTrack()
+		assert.DeepEqual(t, []domain.InvaderCard{}, track.Build)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneWetland},
+			track.Explore,
+		)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneUnknown},
+			track.Discard,
+		)
+
+		assert.NilError(t, game.Undo())
+		assert.Equal(
+			t,
+			"1J* 1W* | 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+			deckString(game),
+		)
+		assert.DeepEqual(t, []domain.InvaderCard{}, game.InvaderTrack().Discard)
+	})
+
+	t.Run("UndoRedo", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{})
+		assert.ErrorIs(t, game.Undo(), domain.ErrNothingToUndo)
+		assert.ErrorIs(t, game.Redo(), domain.ErrNothingToRedo)
+
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		assert.NilError(t, game.Draw(domain.StageOneWetland))
+		drawn := deckString(game)
+
+		assert.NilError(t, game.Undo())
+		assert.Equal(
+			t,
+			"1J* | 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+			deckString(game),
+		)
+		assert.Assert(t, !game.InvaderCardpool().Revealed[1].Contains(
+			domain.StageOneWetland,
+		))
+
+		assert.NilError(t, game.Undo())
+		assert.ErrorIs(t, game.Undo(), domain.ErrNothingToUndo)
+		assert.Equal(t, 0, game.InvaderCardpool().Revealed[1].Cardinality())
+
+		assert.NilError(t, game.Redo())
+		assert.NilError(t, game.Redo())
+		assert.ErrorIs(t, game.Redo(), domain.ErrNothingToRedo)
+		assert.Equal(t, drawn, deckString(game))
+		assert.Assert(t, game.InvaderCardpool().Revealed[1].Contains(
+			domain.StageOneJungle,
+			domain.StageOneWetland,
+		))
+
+		// A new event can't be redone over.
+		assert.NilError(t, game.Undo())
+		assert.NilError(t, game.Draw(domain.StageOneSands))
+		assert.ErrorIs(t, game.Redo(), domain.ErrNothingToRedo)
+		assert.DeepEqual(t, []domain.Event{
+			domain.CardDrawn{domain.StageOneJungle},
+			domain.CardDrawn{domain.StageOneSands},
+		}, game.Events())
+	})
+}
+
+//nolint:exhaustruct
+func TestInitializedGame_Atomic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Rollback", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      domain.Russia,
+			LeadingAdversaryLevel: 5,
+		})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		before := deckString(game)
+
+		// The deck accepts any Stage I card but the cardpool doesn't.
+		err := game.Draw(domain.InvaderCard{1, domain.Jungle, domain.Wetland})
+		assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+		err = game.Entrenched(domain.InvaderCard{2, "not-a-terrain", ""})
+		assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+
+		assert.Equal(t, before, deckString(game))
+		assert.Equal(t, 1, game.InvaderCardpool().Revealed[1].Cardinality())
+		assert.Equal(t, 1, len(game.Events()))
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+
+		deck := game.InvaderDeck()
+		assert.NilError(t, deck.Draw(domain.StageOneWetland))
+		deck.Drawn[0] = domain.InvaderCardDrawn{}
+		icp := game.InvaderCardpool()
+		assert.NilError(t, icp.Reveal(domain.StageOneWetland))
+
+		assert.Equal(
+			t,
+			"1J* | 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+			deckString(game),
+		)
+		assert.Assert(t, !game.InvaderCardpool().Revealed[1].Contains(
+			domain.StageOneWetland,
+		))
+	})
+}
+
+//nolint:exhaustruct
+func TestInitializedGame_Removed(t *testing.T) {
+	t.Parallel()
+
+	game := initGame(t, &domain.Game{
+		LeadingAdversary:      domain.HabsburgMines,
+		LeadingAdversaryLevel: 4,
+	})
+	for _, c := range []domain.InvaderCard{
+		domain.StageOneJungle,
+		domain.StageOneWetland,
+		domain.StageOneSands,
+	} {
+		assert.NilError(t, game.Draw(c))
+	}
+
+	err := game.Draw(domain.StageTwoCoastal)
+	assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+	assert.Equal(
+		t,
+		domain.RemovedCardError{domain.StageTwoCoastal},
+		err,
+	)
+	assert.NilError(t, game.Draw(domain.StageTwoMountain))
+}
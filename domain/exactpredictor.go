@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ExactPredictor gives exact probabilities for the cards left in the deck.
+// It enumerates every way the unseen cards can fill the unknown cards
+// of the invader deck, each of which is equally likely.
+// Unlike InvaderCardpool.Predict this accounts for the correlations
+// between cards (e.g. the terrain pairs left on the Stage III cards).
+type ExactPredictor struct {
+	indeck []InvaderCardInDeck
+	// unseen are the physical cards of each stage which could still be drawn.
+	unseen map[int][]InvaderCard
+	// memo caches the result of every query.
+	memo map[string]float64
+}
+
+// NewExactPredictor creates a predictor for the invader deck.
+// Cards are unseen when they aren't revealed in the cardpool
+// and aren't already known in the deck.
+func NewExactPredictor(deck InvaderDeck, icp InvaderCardpool) *ExactPredictor {
+	known := make(map[InvaderCard]bool)
+	for _, c := range deck.InDeck {
+		known[c.InvaderCard] = true
+	}
+
+	unseen := make(map[int][]InvaderCard, 3)
+	for _, c := range AllInvaderCards {
+		if known[c] {
+			continue
+		}
+		if revealed, ok := icp.Revealed[c.Stage]; ok && revealed.Contains(c) {
+			continue
+		}
+		unseen[c.Stage] = append(unseen[c.Stage], c)
+	}
+
+	indeck := make([]InvaderCardInDeck, len(deck.InDeck))
+	copy(indeck, deck.InDeck)
+
+	return &ExactPredictor{
+		indeck: indeck,
+		unseen: unseen,
+		memo:   make(map[string]float64),
+	}
+}
+
+// ExactPredictor creates an exact predictor for the current invader deck.
+func (g *InitializedGame) ExactPredictor() *ExactPredictor {
+	return NewExactPredictor(*g.invaderdeck, *g.invadercardpool)
+}
+
+// Chance is the probability that the terrain is on any of the cards
+// at the given positions of the deck, 0 being the next card to be drawn.
+// Positions past the bottom of the deck never have the terrain.
+func (ep *ExactPredictor) Chance(terrain Terrain, positions ...int) float64 {
+	var key strings.Builder
+	key.WriteString(string(terrain))
+	for _, p := range positions {
+		key.WriteString(":" + strconv.Itoa(p))
+	}
+	if c, ok := ep.memo[key.String()]; ok {
+		return c
+	}
+
+	cards := make([]InvaderCard, 0, len(positions))
+	for _, p := range positions {
+		if p >= 0 && p < len(ep.indeck) {
+			cards = append(cards, ep.indeck[p].InvaderCard)
+		}
+	}
+
+	c := (&chance{
+		ep:      ep,
+		terrain: terrain,
+		cards:   cards,
+		memo:    make(map[chanceState]float64),
+	}).of(chanceState{})
+	ep.memo[key.String()] = c
+
+	return c
+}
+
+// Predict is the exact probability of each terrain for the card
+// at the given position of the deck, 0 being the next card to be drawn.
+// Terrains which can't be on the card are left out.
+func (ep *ExactPredictor) Predict(position int) map[Terrain]float64 {
+	pcts := make(map[Terrain]float64)
+	for _, t := range AllTerrains {
+		if c := ep.Chance(t, position); c > 0 {
+			pcts[t] = c
+		}
+	}
+
+	return pcts
+}
+
+// chance enumerates the cards for a single query.
+type chance struct {
+	ep      *ExactPredictor
+	terrain Terrain
+	cards   []InvaderCard
+	memo    map[chanceState]float64
+}
+
+// chanceState is the next card of the query and the unseen cards used so far.
+// The used cards never have the terrain, otherwise the query is settled.
+type chanceState struct {
+	next int
+	used uint16
+}
+
+func (ch *chance) of(state chanceState) float64 {
+	if state.next == len(ch.cards) {
+		return 0
+	}
+	if c, ok := ch.memo[state]; ok {
+		return c
+	}
+
+	card := ch.cards[state.next]
+	following := chanceState{state.next + 1, state.used}
+	var c float64
+	if card.Terrain != UnknownTerrain {
+		if card.Terrain == ch.terrain || card.Terrain2 == ch.terrain {
+			c = 1
+		} else {
+			c = ch.of(following)
+		}
+	} else {
+		candidates := 0
+		for _, u := range ch.ep.unseen[card.Stage] {
+			bit := cardBit(u)
+			if state.used&bit != 0 {
+				continue
+			}
+			candidates++
+			if u.Terrain == ch.terrain || u.Terrain2 == ch.terrain {
+				c++
+			} else {
+				c += ch.of(chanceState{state.next + 1, state.used | bit})
+			}
+		}
+		if candidates != 0 {
+			c /= float64(candidates)
+		}
+	}
+
+	ch.memo[state] = c
+
+	return c
+}
+
+// cardBit is a unique bit for each of the physical invader cards.
+func cardBit(card InvaderCard) uint16 {
+	for i, c := range AllInvaderCards {
+		if c == card {
+			return 1 << i
+		}
+	}
+
+	return 0
+}
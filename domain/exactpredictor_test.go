@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+func assertChance(t *testing.T, want float64, got float64) {
+	t.Helper()
+
+	assert.Assert(t, math.Abs(want-got) < 1e-9, "%v != %v", want, got)
+}
+
+//nolint:exhaustruct
+func TestExactPredictor_Chance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Uniform", func(t *testing.T) {
+		t.Parallel()
+
+		// The first card matches the simple prediction.
+		ep := initGame(t, &domain.Game{}).ExactPredictor()
+		assert.DeepEqual(t, map[domain.Terrain]float64{
+			domain.Jungle:   0.25,
+			domain.Mountain: 0.25,
+			domain.Sands:    0.25,
+			domain.Wetland:  0.25,
+		}, ep.Predict(0))
+
+		// All but one Stage I terrain is on the first three cards.
+		assertChance(t, 0.75, ep.Chance(domain.Jungle, 0, 1, 2))
+		assertChance(t, 0.0, ep.Chance(domain.Jungle, 12, 13))
+	})
+
+	t.Run("Correlated", func(t *testing.T) {
+		t.Parallel()
+
+		// Jungle is on 1 of the 4 Stage III cards left.
+		deck := domain.InvaderDeck{InDeck: []domain.InvaderCardInDeck{
+			{domain.StageThreeUnknown, false},
+			{domain.StageThreeUnknown, false},
+			{domain.StageThreeUnknown, false},
+		}}
+		icp := domain.NewInvaderCardpool(&domain.Game{})
+		assert.NilError(t, icp.Reveal(domain.StageThreeJungleMountain))
+		assert.NilError(t, icp.Reveal(domain.StageThreeJungleSands))
+
+		ep := domain.NewExactPredictor(deck, *icp)
+		assertChance(t, 0.25, ep.Chance(domain.Jungle, 0))
+		assertChance(t, 0.5, ep.Chance(domain.Jungle, 0, 1))
+		assertChance(t, 0.75, ep.Chance(domain.Jungle, 0, 1, 2))
+		assertChance(t, 1.0, ep.Chance(domain.Wetland, 0, 1))
+	})
+
+	t.Run("Known", func(t *testing.T) {
+		t.Parallel()
+
+		// Scotland places the coastal card further down the deck.
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      domain.Scotland,
+			LeadingAdversaryLevel: 4,
+		})
+		ep := game.ExactPredictor()
+		for i, c := range game.InvaderDeck().InDeck {
+			want := 0.0
+			if c.InvaderCard == domain.StageTwoCoastal {
+				want = 1.0
+			}
+			assertChance(t, want, ep.Chance(domain.CoastalLands, i))
+		}
+	})
+
+	t.Run("FullDeck", func(t *testing.T) {
+		t.Parallel()
+
+		ep := initGame(t, &domain.Game{}).ExactPredictor()
+		all := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+
+		// Half of the Stage III cards are jungle
+		// but one of the Stage II cards is left out of the deck.
+		start := time.Now()
+		assertChance(t, 1.0, ep.Chance(domain.Jungle, all...))
+		assertChance(t, 0.8, ep.Chance(domain.CoastalLands, all...))
+		assert.Assert(t, time.Since(start) < time.Second)
+	})
+}
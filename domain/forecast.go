@@ -0,0 +1,147 @@
+package domain
+
+// Forecast is the chance of each terrain during a single Invader Phase.
+// Terrains which can't be ravaged, built, or explored are left out.
+type Forecast struct {
+	Ravage  map[Terrain]float64 `json:"ravage"`
+	Build   map[Terrain]float64 `json:"build"`
+	Explore map[Terrain]float64 `json:"explore"`
+}
+
+// Forecast the terrains of the next Invader Phases.
+// The cards follow the order of the invader deck (including adversary setup)
+// and then advance along the invader track.
+// Unknown cards are predicted from the revealed cards of their stage,
+// each card is predicted independently of the others.
+func (icp InvaderCardpool) Forecast(
+	deck InvaderDeck,
+	track InvaderTrack,
+	turns int,
+) ([]Forecast, error) {
+	predict := func(_ int, card InvaderCard) (map[Terrain]float64, error) {
+		return icp.Predict(card.Stage)
+	}
+
+	return forecast(deck, track, turns, predict)
+}
+
+// predictCard predicts an unknown card, the position is where it was
+// in the invader deck or -1 when it was already on the invader track.
+type predictCard func(position int, card InvaderCard) (
+	map[Terrain]float64,
+	error,
+)
+
+// forecastCard is a card on the invader track during a forecast.
+type forecastCard struct {
+	InvaderCard
+	position int
+}
+
+func forecast(
+	deck InvaderDeck,
+	track InvaderTrack,
+	turns int,
+	predict predictCard,
+) ([]Forecast, error) {
+	next := track.clone()
+	// The cards explored this turn build in the next Invader Phase.
+	if len(next.Explore) != 0 {
+		next.Advance()
+	}
+
+	forecasts := []Forecast{}
+	for turn := 0; turn < turns; turn++ {
+		if turn < len(deck.InDeck) {
+			next.Explore = []InvaderCard{deck.InDeck[turn].InvaderCard}
+		}
+
+		// Deck cards are explored on their turn and advance once a turn.
+		explored := func(cards []InvaderCard, ago int) []forecastCard {
+			pos := turn - ago
+			if pos < 0 || pos >= len(deck.InDeck) {
+				pos = -1
+			}
+			fcs := make([]forecastCard, 0, len(cards))
+			for _, c := range cards {
+				fcs = append(fcs, forecastCard{c, pos})
+			}
+
+			return fcs
+		}
+
+		ravage := explored(next.Ravage, 2)
+		// High Immigration builds along with the Build space.
+		builds := append(
+			explored(next.HighImmigration, 3),
+			explored(next.Build, 1)...,
+		)
+		explore := explored(next.Explore, 0)
+
+		var fc Forecast
+		var err error
+		if fc.Ravage, err = terrains(ravage, predict); err != nil {
+			return nil, err
+		}
+		if fc.Build, err = terrains(builds, predict); err != nil {
+			return nil, err
+		}
+		if fc.Explore, err = terrains(explore, predict); err != nil {
+			return nil, err
+		}
+		forecasts = append(forecasts, fc)
+
+		next.Advance()
+	}
+
+	return forecasts, nil
+}
+
+// terrains is the chance of each terrain being on any of the cards.
+func terrains(
+	cards []forecastCard,
+	predict predictCard,
+) (map[Terrain]float64, error) {
+	chance := make(map[Terrain]float64)
+	for _, c := range cards {
+		pcts := cardTerrains(c.InvaderCard)
+		if c.Terrain == UnknownTerrain {
+			var err error
+			if pcts, err = predict(c.position, c.InvaderCard); err != nil {
+				return nil, err
+			}
+		}
+
+		for t, p := range pcts {
+			if p > 0.0 {
+				chance[t] += p - chance[t]*p
+			}
+		}
+	}
+
+	return chance, nil
+}
+
+// Forecast the terrains of the next Invader Phases of the game.
+// The cards of the invader deck are predicted with the game's predictor,
+// the unknown cards already on the invader track are predicted
+// from the revealed cards of their stage.
+func (g *InitializedGame) Forecast(turns int) ([]Forecast, error) {
+	p, err := g.NewPredictor()
+	if err != nil {
+		return nil, err
+	}
+
+	return forecast(
+		*g.invaderdeck,
+		*g.invadertrack,
+		turns,
+		func(position int, card InvaderCard) (map[Terrain]float64, error) {
+			if position < 0 {
+				return g.invadercardpool.Predict(card.Stage)
+			}
+
+			return p.Predict(position), nil
+		},
+	)
+}
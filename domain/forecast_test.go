@@ -0,0 +1,194 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+// positionPredictor predicts a terrain for each position of the deck.
+type positionPredictor []domain.Terrain
+
+func (pp positionPredictor) Predict(position int) map[domain.Terrain]float64 {
+	return map[domain.Terrain]float64{pp[position]: 1}
+}
+
+//nolint:exhaustruct
+func TestInitializedGame_Forecast(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NewGame", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{})
+		forecasts, err := game.Forecast(2)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, []domain.Forecast{
+			{
+				Ravage: map[domain.Terrain]float64{},
+				Build:  map[domain.Terrain]float64{},
+				Explore: map[domain.Terrain]float64{
+					domain.Jungle:   0.25,
+					domain.Mountain: 0.25,
+					domain.Sands:    0.25,
+					domain.Wetland:  0.25,
+				},
+			},
+			{
+				Ravage: map[domain.Terrain]float64{},
+				Build: map[domain.Terrain]float64{
+					domain.Jungle:   0.25,
+					domain.Mountain: 0.25,
+					domain.Sands:    0.25,
+					domain.Wetland:  0.25,
+				},
+				Explore: map[domain.Terrain]float64{
+					domain.Jungle:   0.25,
+					domain.Mountain: 0.25,
+					domain.Sands:    0.25,
+					domain.Wetland:  0.25,
+				},
+			},
+		}, forecasts)
+	})
+
+	t.Run("Track", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		assert.NilError(t, game.Advance())
+		assert.NilError(t, game.Draw(domain.StageOneWetland))
+
+		// The wetland was explored this turn so it builds next turn.
+		forecasts, err := game.Forecast(3)
+		assert.NilError(t, err)
+		assert.Equal(t, 3, len(forecasts))
+		assert.DeepEqual(
+			t,
+			map[domain.Terrain]float64{domain.Jungle: 1},
+			forecasts[0].Ravage,
+		)
+		assert.DeepEqual(
+			t,
+			map[domain.Terrain]float64{domain.Wetland: 1},
+			forecasts[0].Build,
+		)
+		assert.DeepEqual(
+			t,
+			map[domain.Terrain]float64{
+				domain.Mountain: 0.5,
+				domain.Sands:    0.5,
+			},
+			forecasts[0].Explore,
+		)
+		assert.DeepEqual(
+			t,
+			map[domain.Terrain]float64{domain.Wetland: 1},
+			forecasts[1].Ravage,
+		)
+		assert.Equal(t, 0.2, forecasts[2].Explore[domain.CoastalLands])
+	})
+
+	t.Run("SpeciallyPlaced", func(t *testing.T) {
+		t.Parallel()
+
+		// Scotland places the coastal card further down the deck.
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      domain.Scotland,
+			LeadingAdversaryLevel: 4,
+		})
+		deck := game.InvaderDeck()
+
+		forecasts, err := game.Forecast(len(deck.InDeck))
+		assert.NilError(t, err)
+		for i, c := range deck.InDeck {
+			if c.InvaderCard == domain.StageTwoCoastal {
+				assert.DeepEqual(
+					t,
+					map[domain.Terrain]float64{domain.CoastalLands: 1},
+					forecasts[i].Explore,
+				)
+
+				continue
+			}
+			assert.Equal(t, 0.0, forecasts[i].Explore[domain.CoastalLands])
+		}
+	})
+
+	t.Run("HighImmigration", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      domain.England,
+			LeadingAdversaryLevel: 4,
+		})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		assert.NilError(t, game.Advance())
+		assert.NilError(t, game.Draw(domain.StageOneWetland))
+		assert.NilError(t, game.Advance())
+
+		// The jungle builds again from High Immigration.
+		forecasts, err := game.Forecast(2)
+		assert.NilError(t, err)
+		assert.DeepEqual(
+			t,
+			map[domain.Terrain]float64{domain.Wetland: 1},
+			forecasts[0].Build,
+		)
+		assert.DeepEqual(
+			t,
+			map[domain.Terrain]float64{
+				domain.Jungle:   1,
+				domain.Mountain: 0.5,
+				domain.Sands:    0.5,
+			},
+			forecasts[1].Build,
+		)
+	})
+
+	t.Run("Predictor", func(t *testing.T) {
+		t.Parallel()
+
+		domain.RegisterPredictor(
+			"forecast-by-position",
+			func(domain.Snapshot, int64) domain.Predictor {
+				return positionPredictor{
+					domain.Jungle,
+					domain.Wetland,
+					domain.Sands,
+				}
+			},
+		)
+
+		// The uniform predictor can't tell the unknown cards apart.
+		uniform, err := initGame(t, &domain.Game{}).Forecast(3)
+		assert.NilError(t, err)
+		assert.Equal(t, 4, len(uniform[0].Explore))
+
+		game := initGame(t, &domain.Game{Predictor: "forecast-by-position"})
+		forecasts, err := game.Forecast(3)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, []domain.Forecast{
+			{
+				Ravage:  map[domain.Terrain]float64{},
+				Build:   map[domain.Terrain]float64{},
+				Explore: map[domain.Terrain]float64{domain.Jungle: 1},
+			},
+			{
+				Ravage:  map[domain.Terrain]float64{},
+				Build:   map[domain.Terrain]float64{domain.Jungle: 1},
+				Explore: map[domain.Terrain]float64{domain.Wetland: 1},
+			},
+			{
+				Ravage:  map[domain.Terrain]float64{domain.Jungle: 1},
+				Build:   map[domain.Terrain]float64{domain.Wetland: 1},
+				Explore: map[domain.Terrain]float64{domain.Sands: 1},
+			},
+		}, forecasts)
+
+		_, err = initGame(t, &domain.Game{Predictor: "psychic"}).Forecast(1)
+		assert.ErrorIs(t, err, domain.ErrUnknownPredictor)
+	})
+}
@@ -1,11 +1,102 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidGame occurs when the adversaries of a game can't be played.
+var ErrInvalidGame = errors.New("invalid game")
+
+// UnknownAdversaryError occurs when an adversary isn't registered.
+type UnknownAdversaryError struct {
+	Adversary Adversary
+}
+
+func (err UnknownAdversaryError) Error() string {
+	return fmt.Sprintf("%q is not an adversary", err.Adversary)
+}
+
+// Is makes UnknownAdversaryError an ErrInvalidGame.
+func (err UnknownAdversaryError) Is(target error) bool {
+	return target == ErrInvalidGame
+}
+
+// AdversaryLevelError occurs when an adversary is played at a level it
+// doesn't have, or a level is given without an adversary.
+type AdversaryLevelError struct {
+	// Adversary is UnknownAdversary when the level has no adversary.
+	Adversary Adversary
+	Level     int
+	MinLevel  int
+	MaxLevel  int
+}
+
+func (err AdversaryLevelError) Error() string {
+	if err.Adversary == UnknownAdversary {
+		return fmt.Sprintf("level %d has no adversary", err.Level)
+	}
+
+	return fmt.Sprintf(
+		"%s is played at levels %d to %d, not %d",
+		err.Adversary,
+		err.MinLevel,
+		err.MaxLevel,
+		err.Level,
+	)
+}
+
+// Is makes AdversaryLevelError an ErrInvalidGame.
+func (err AdversaryLevelError) Is(target error) bool {
+	return target == ErrInvalidGame
+}
+
+// DuplicateAdversaryError occurs when an adversary both leads and supports.
+type DuplicateAdversaryError struct {
+	Adversary Adversary
+}
+
+func (err DuplicateAdversaryError) Error() string {
+	return fmt.Sprintf("%s can't both lead and support", err.Adversary)
+}
+
+// Is makes DuplicateAdversaryError an ErrInvalidGame.
+func (err DuplicateAdversaryError) Is(target error) bool {
+	return target == ErrInvalidGame
+}
+
+// NoLeadingAdversaryError occurs when there is only a supporting adversary.
+type NoLeadingAdversaryError struct {
+	Supporting Adversary
+}
+
+func (err NoLeadingAdversaryError) Error() string {
+	return fmt.Sprintf(
+		"%s can't support without a leading adversary",
+		err.Supporting,
+	)
+}
+
+// Is makes NoLeadingAdversaryError an ErrInvalidGame.
+func (err NoLeadingAdversaryError) Is(target error) bool {
+	return target == ErrInvalidGame
+}
+
 // Game orchestrates and owns the various state containers.
 type Game struct {
-	LeadingAdversary         Adversary
-	LeadingAdversaryLevel    int
-	SupportingAdversary      Adversary
-	SupportingAdversaryLevel int
+	LeadingAdversary         Adversary `json:"leadingAdversary"`
+	LeadingAdversaryLevel    int       `json:"leadingAdversaryLevel"`
+	SupportingAdversary      Adversary `json:"supportingAdversary"`
+	SupportingAdversaryLevel int       `json:"supportingAdversaryLevel"`
+
+	// Predictor is the name of the registered predictor used for the game.
+	Predictor string `json:"predictor,omitempty"`
+	// Seed is for anything random about the game (e.g. the predictor).
+	// A seed of 0 is unset, see SeedVirtual.
+	Seed int64 `json:"seed,omitempty"`
+	// Virtual is true when the game deals the invader cards
+	// instead of them being drawn from a physical deck.
+	Virtual bool `json:"virtual,omitempty"`
 }
 
 // Initialized Game is a domain.Game with initialized state containers.
@@ -14,16 +105,141 @@ type InitializedGame struct {
 
 	invadercardpool *InvaderCardpool
 	invaderdeck     *InvaderDeck
+	invadertrack    *InvaderTrack
+
+	// base is the state the events are replayed onto.
+	base   gameState
+	events []Event
+	undone []Event
 }
 
-// Init initialized the given game.
-func (g *Game) Init() *InitializedGame {
+// gameState is a snapshot of the state containers.
+type gameState struct {
+	invadercardpool *InvaderCardpool
+	invaderdeck     *InvaderDeck
+	invadertrack    *InvaderTrack
+}
+
+// Validate checks the adversaries of the game can be played together.
+func (g *Game) Validate() error {
+	if g.LeadingAdversary == UnknownAdversary &&
+		g.SupportingAdversary != UnknownAdversary {
+		return NoLeadingAdversaryError{g.SupportingAdversary}
+	}
+	if g.LeadingAdversary != UnknownAdversary &&
+		g.LeadingAdversary == g.SupportingAdversary {
+		return DuplicateAdversaryError{g.LeadingAdversary}
+	}
+
+	for _, adv := range []struct {
+		adversary Adversary
+		level     int
+	}{
+		{g.LeadingAdversary, g.LeadingAdversaryLevel},
+		{g.SupportingAdversary, g.SupportingAdversaryLevel},
+	} {
+		if adv.adversary == UnknownAdversary {
+			if adv.level != 0 {
+				return AdversaryLevelError{Level: adv.level}
+			}
+
+			continue
+		}
+
+		def, ok := LookupAdversary(adv.adversary)
+		if !ok {
+			return UnknownAdversaryError{adv.adversary}
+		}
+		if adv.level < def.MinLevel || adv.level > def.MaxLevel {
+			return AdversaryLevelError{
+				adv.adversary,
+				adv.level,
+				def.MinLevel,
+				def.MaxLevel,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Init validates and initializes the given game.
+// Virtual games without a seed are given a random one.
+func (g *Game) Init() (*InitializedGame, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	g.SeedVirtual()
+
 	init := &InitializedGame{
 		Game: g,
 
-		invadercardpool: NewInvaderCardpool(g),
-		invaderdeck:     NewInvaderDeck(g),
+		base: gameState{
+			invadercardpool: NewInvaderCardpool(g),
+			invaderdeck:     NewInvaderDeck(g),
+			invadertrack:    NewInvaderTrack(g),
+		},
+	}
+	init.setState(init.base.clone())
+
+	return init, nil
+}
+
+// InvaderDeck is a copy of the invader deck for the game.
+// Use the methods of the InitializedGame to change it.
+func (g *InitializedGame) InvaderDeck() InvaderDeck {
+	return *g.invaderdeck.clone()
+}
+
+// InvaderCardpool is a copy of the invader cardpool for the game.
+// Use the methods of the InitializedGame to change it.
+func (g *InitializedGame) InvaderCardpool() InvaderCardpool {
+	return *g.invadercardpool.clone()
+}
+
+// InvaderTrack is a copy of the invader track for the game.
+// Use the methods of the InitializedGame to change it.
+func (g *InitializedGame) InvaderTrack() InvaderTrack {
+	return *g.invadertrack.clone()
+}
+
+func (g *InitializedGame) state() gameState {
+	return gameState{g.invadercardpool, g.invaderdeck, g.invadertrack}
+}
+
+func (g *InitializedGame) setState(state gameState) {
+	g.invadercardpool = state.invadercardpool
+	g.invaderdeck = state.invaderdeck
+	g.invadertrack = state.invadertrack
+}
+
+func (state gameState) clone() gameState {
+	return gameState{
+		state.invadercardpool.clone(),
+		state.invaderdeck.clone(),
+		state.invadertrack.clone(),
+	}
+}
+
+// checkRemoved checks that the card wasn't revealed without being in the deck.
+// This happens when adversary setup removes the card
+// (e.g. Coastal Lands for the Habsburg Mining Expedition).
+func (state gameState) checkRemoved(card InvaderCard) error {
+	if card.Stage < 1 || card.Stage > 3 ||
+		!state.invadercardpool.Revealed[card.Stage].Contains(card) {
+		return nil
+	}
+
+	for _, c := range state.invaderdeck.Drawn {
+		if c.InvaderCard == card {
+			return nil
+		}
+	}
+	for _, c := range state.invaderdeck.InDeck {
+		if c.InvaderCard == card {
+			return nil
+		}
 	}
 
-	return init
+	return RemovedCardError{card}
 }
@@ -0,0 +1,120 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+// initGame initializes a game which is known to be valid.
+func initGame(t *testing.T, game *domain.Game) *domain.InitializedGame {
+	t.Helper()
+
+	init, err := game.Init()
+	assert.NilError(t, err)
+
+	return init
+}
+
+// deckString is the notation of the game's invader deck.
+func deckString(game *domain.InitializedGame) string {
+	deck := game.InvaderDeck()
+
+	return deck.String()
+}
+
+//nolint:exhaustruct
+func TestGame_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, g := range []domain.Game{
+			{},
+			{LeadingAdversary: domain.England},
+			{LeadingAdversary: domain.England, LeadingAdversaryLevel: 6},
+			{
+				LeadingAdversary:         domain.Russia,
+				LeadingAdversaryLevel:    3,
+				SupportingAdversary:      domain.Scotland,
+				SupportingAdversaryLevel: 2,
+			},
+		} {
+			g := g
+			assert.NilError(t, g.Validate(), g)
+		}
+	})
+
+	for n, tc := range map[string]struct {
+		game domain.Game
+		err  error
+	}{
+		"UnknownLeading": {
+			domain.Game{LeadingAdversary: "atlantis"},
+			domain.UnknownAdversaryError{Adversary: "atlantis"},
+		},
+		"UnknownSupporting": {
+			domain.Game{
+				LeadingAdversary:    domain.England,
+				SupportingAdversary: "atlantis",
+			},
+			domain.UnknownAdversaryError{Adversary: "atlantis"},
+		},
+		"LevelTooHigh": {
+			domain.Game{
+				LeadingAdversary:      domain.Russia,
+				LeadingAdversaryLevel: 7,
+			},
+			domain.AdversaryLevelError{
+				Adversary: domain.Russia,
+				Level:     7,
+				MaxLevel:  6,
+			},
+		},
+		"LevelTooLow": {
+			domain.Game{
+				LeadingAdversary:         domain.Russia,
+				SupportingAdversary:      domain.France,
+				SupportingAdversaryLevel: -1,
+			},
+			domain.AdversaryLevelError{
+				Adversary: domain.France,
+				Level:     -1,
+				MaxLevel:  6,
+			},
+		},
+		"LevelWithoutAdversary": {
+			domain.Game{
+				LeadingAdversary:         domain.Russia,
+				SupportingAdversaryLevel: 2,
+			},
+			domain.AdversaryLevelError{Level: 2},
+		},
+		"Duplicate": {
+			domain.Game{
+				LeadingAdversary:    domain.Sweden,
+				SupportingAdversary: domain.Sweden,
+			},
+			domain.DuplicateAdversaryError{Adversary: domain.Sweden},
+		},
+		"SupportingWithoutLeading": {
+			domain.Game{SupportingAdversary: domain.Scotland},
+			domain.NoLeadingAdversaryError{Supporting: domain.Scotland},
+		},
+	} {
+		tc := tc
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.game.Validate()
+			assert.ErrorIs(t, err, domain.ErrInvalidGame)
+			assert.Equal(t, err, tc.err)
+
+			init, err := tc.game.Init()
+			assert.Assert(t, init == nil)
+			assert.Equal(t, err, tc.err)
+		})
+	}
+}
@@ -1,11 +1,66 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // ErrInvalidInvaderCard occurs when a stage/terrain is violated.
-// TODO: Maybe make this more granular.
+// The more specific errors below are all an ErrInvalidInvaderCard.
 var ErrInvalidInvaderCard = errors.New("invalid invader card")
 
+// StageMismatchError occurs when a card is not of the expected stage.
+type StageMismatchError struct {
+	Expected int
+	Got      int
+}
+
+func (err StageMismatchError) Error() string {
+	return fmt.Sprintf(
+		"expected a stage %d invader card, not stage %d",
+		err.Expected,
+		err.Got,
+	)
+}
+
+// Is makes StageMismatchError an ErrInvalidInvaderCard.
+func (err StageMismatchError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
+// InvalidStageError occurs when a stage is not I, II, or III.
+type InvalidStageError struct {
+	Stage int
+}
+
+func (err InvalidStageError) Error() string {
+	return fmt.Sprintf(
+		"%d is not a stage, expected I, II, or III",
+		err.Stage,
+	)
+}
+
+// Is makes InvalidStageError an ErrInvalidInvaderCard.
+func (err InvalidStageError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
+// UnknownCardError occurs when a card is not one of the physical cards.
+type UnknownCardError struct {
+	Card InvaderCard
+}
+
+func (err UnknownCardError) Error() string {
+	return fmt.Sprintf("%s is not an invader card", err.Card)
+}
+
+// Is makes UnknownCardError an ErrInvalidInvaderCard.
+func (err UnknownCardError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
 // InvaderCard has a phase and one or more terrain types.
 type InvaderCard struct {
 	Stage    int
@@ -89,3 +144,90 @@ var (
 		append(StageOneInvaderCards, StageTwoInvaderCards...),
 		StageThreeInvaderCards...)
 )
+
+// The notation for each terrain, used to build the notation of a card.
+var terrainNotation = map[Terrain]string{
+	Jungle:                       "J",
+	Mountain:                     "M",
+	Sands:                        "S",
+	Wetland:                      "W",
+	CoastalLands:                 "C",
+	StageTwoSaltDeposits.Terrain: "SALT",
+	UnknownTerrain:               "?",
+}
+
+// Every card which can be parsed, keyed by its notation.
+var cardNotation = func() map[string]InvaderCard {
+	cards := append(
+		[]InvaderCard{
+			StageTwoSaltDeposits,
+			StageOneUnknown,
+			StageTwoUnknown,
+			StageThreeUnknown,
+		},
+		AllInvaderCards...)
+
+	notation := make(map[string]InvaderCard, len(cards)+6)
+	for _, c := range cards {
+		notation[c.String()] = c
+		if c.Terrain2 != UnknownTerrain {
+			// Stage III terrains can be given in either order.
+			notation[strconv.Itoa(c.Stage)+
+				terrainNotation[c.Terrain2]+
+				terrainNotation[c.Terrain]] = c
+		}
+	}
+
+	return notation
+}()
+
+// ParseInvaderCard parses the compact notation of an invader card.
+// The notation is the stage followed by the initial of each terrain
+// (e.g. 1J, 2C, or 3MS), 2SALT for Salt Deposits,
+// or a ? in place of the terrain when it isn't known (e.g. 3?).
+func ParseInvaderCard(s string) (InvaderCard, error) {
+	card, ok := cardNotation[strings.ToUpper(strings.TrimSpace(s))]
+	if !ok {
+		return InvaderCard{}, fmt.Errorf(
+			"%w: %q is not a card",
+			ErrInvalidInvaderCard,
+			s,
+		)
+	}
+
+	return card, nil
+}
+
+// String formats the invader card using the compact notation.
+func (card InvaderCard) String() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(card.Stage))
+	for i, t := range []Terrain{card.Terrain, card.Terrain2} {
+		if i > 0 && t == UnknownTerrain {
+			break
+		}
+		if n, ok := terrainNotation[t]; ok {
+			sb.WriteString(n)
+		} else {
+			sb.WriteString("(" + string(t) + ")")
+		}
+	}
+
+	return sb.String()
+}
+
+// MarshalText implements encoding.TextMarshaler using the compact notation.
+func (card InvaderCard) MarshalText() ([]byte, error) {
+	return []byte(card.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using the compact notation.
+func (card *InvaderCard) UnmarshalText(text []byte) error {
+	c, err := ParseInvaderCard(string(text))
+	if err != nil {
+		return err
+	}
+	*card = c
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestInvaderCard_Notation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		t.Parallel()
+
+		cards := append(
+			[]domain.InvaderCard{
+				domain.StageTwoSaltDeposits,
+				domain.StageOneUnknown,
+				domain.StageTwoUnknown,
+				domain.StageThreeUnknown,
+			},
+			domain.AllInvaderCards...)
+		for _, c := range cards {
+			p, err := domain.ParseInvaderCard(c.String())
+			assert.NilError(t, err, c)
+			assert.Equal(t, c, p)
+		}
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		t.Parallel()
+
+		cases := []struct {
+			notation string
+			card     domain.InvaderCard
+		}{
+			{"1J", domain.StageOneJungle},
+			{"2w", domain.StageTwoWetland},
+			{" 2C ", domain.StageTwoCoastal},
+			{"2SALT", domain.StageTwoSaltDeposits},
+			{"3MS", domain.StageThreeMountainSands},
+			{"3SM", domain.StageThreeMountainSands},
+			{"3?", domain.StageThreeUnknown},
+		}
+
+		for _, tc := range cases {
+			tc := tc
+			t.Run(tc.notation, func(t *testing.T) {
+				t.Parallel()
+
+				c, err := domain.ParseInvaderCard(tc.notation)
+				assert.NilError(t, err)
+				assert.Equal(t, tc.card, c)
+			})
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, n := range []string{"", "1", "J", "1C", "4J", "3J", "3JJ", "2X"} {
+			_, err := domain.ParseInvaderCard(n)
+			assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard, n)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "3JW", domain.StageThreeJungleWetland.String())
+		assert.Equal(t, "2SALT", domain.StageTwoSaltDeposits.String())
+		assert.Equal(
+			t,
+			"1J(not-a-terrain)",
+			domain.InvaderCard{1, domain.Jungle, "not-a-terrain"}.String(),
+		)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		cards := []domain.InvaderCardDrawn{
+			{domain.StageOneJungle, false},
+			{domain.StageTwoCoastal, true},
+		}
+		j, err := json.Marshal(cards)
+		assert.NilError(t, err)
+		assert.Equal(t, `["1J","2C*"]`, string(j))
+
+		var parsed []domain.InvaderCardDrawn
+		err = json.Unmarshal(j, &parsed)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, cards, parsed)
+
+		err = json.Unmarshal([]byte(`["1X"]`), &parsed)
+		assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+	})
+}
+
+//nolint:exhaustruct
+func TestInvaderDeck_Notation(t *testing.T) {
+	t.Parallel()
+
+	deck := domain.NewInvaderDeck(&domain.Game{
+		LeadingAdversary:      domain.Scotland,
+		LeadingAdversaryLevel: 2,
+	})
+	assert.NilError(t, deck.Draw(domain.StageOneJungle))
+	assert.NilError(t, deck.Draw(domain.StageOneWetland))
+
+	notation := "1J* 1W* | 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?"
+	assert.Equal(t, notation, deck.String())
+
+	parsed := domain.InvaderDeck{}
+	assert.NilError(t, parsed.UnmarshalText([]byte(notation)))
+	assert.DeepEqual(t, deck.Drawn, parsed.Drawn)
+	assert.DeepEqual(t, deck.InDeck, parsed.InDeck)
+
+	assert.Equal(t, "|", (&domain.InvaderDeck{}).String())
+	err := parsed.UnmarshalText([]byte("1J 1W 2?"))
+	assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+}
@@ -1,8 +1,6 @@
 package domain
 
 import (
-	"fmt"
-
 	mapset "github.com/deckarep/golang-set/v2"
 )
 
@@ -20,14 +18,15 @@ func NewInvaderCardpool(game *Game) *InvaderCardpool {
 			3: mapset.NewSetWithSize[InvaderCard](6),
 		},
 	}
-	if (game.LeadingAdversary == Scotland && game.LeadingAdversaryLevel >= 2) ||
-		(game.SupportingAdversary == Scotland && game.SupportingAdversaryLevel >= 2) ||
-		(game.LeadingAdversary == HabsburgMines && game.LeadingAdversaryLevel >= 4) ||
-		(game.SupportingAdversary == HabsburgMines && game.SupportingAdversaryLevel >= 4) {
-
-		err := icp.Reveal(StageTwoCoastal)
-		if err != nil {
-			panic(err)
+	for _, adv := range game.adversaries() {
+		for _, r := range adv.Reveals {
+			if adv.level < r.Level {
+				continue
+			}
+			// The cards are checked when the adversary is registered.
+			if err := icp.Reveal(r.Card); err != nil {
+				panic(err)
+			}
 		}
 	}
 
@@ -75,11 +74,7 @@ func (icp InvaderCardpool) Predict(stage int) (map[Terrain]float64, error) {
 			pcts[trn] = float64(3-revt[trn]) / float64(rem)
 		}
 	default:
-		return nil, fmt.Errorf(
-			"ErrInvalidInvaderCard %w : %d is not a stage, expected I, II, or III",
-			ErrInvalidInvaderCard,
-			stage,
-		)
+		return nil, InvalidStageError{stage}
 	}
 
 	return pcts, nil
@@ -95,5 +90,38 @@ func (icp *InvaderCardpool) Reveal(card InvaderCard) error {
 		}
 	}
 
-	return ErrInvalidInvaderCard
+	return UnknownCardError{card}
+}
+
+// reveal reveals the card if it has a known terrain.
+// Special cards are never part of the predictions.
+func (icp *InvaderCardpool) reveal(card InvaderCard) error {
+	if card.Terrain == UnknownTerrain || card == StageTwoSaltDeposits {
+		return nil
+	}
+
+	return icp.Reveal(card)
+}
+
+func (icp *InvaderCardpool) clone() *InvaderCardpool {
+	revealed := make(map[int]mapset.Set[InvaderCard], len(icp.Revealed))
+	for stg, cards := range icp.Revealed {
+		revealed[stg] = cards.Clone()
+	}
+
+	return &InvaderCardpool{revealed}
+}
+
+func (icp *InvaderCardpool) equal(other *InvaderCardpool) bool {
+	if len(icp.Revealed) != len(other.Revealed) {
+		return false
+	}
+	for stg, cards := range icp.Revealed {
+		ocards, ok := other.Revealed[stg]
+		if !ok || !cards.Equal(ocards) {
+			return false
+		}
+	}
+
+	return true
 }
@@ -200,6 +200,7 @@ func TestInvaderCardPool_Predict(t *testing.T) {
 				icp := domain.NewInvaderCardpool(&domain.Game{})
 				_, err := icp.Predict(tc.stage)
 				assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+				assert.Equal(t, domain.InvalidStageError{tc.stage}, err)
 			})
 		}
 	})
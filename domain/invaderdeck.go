@@ -2,6 +2,8 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 // ErrNoInvaderCard occurs when the an action tries to draw from an empty invader deck.
@@ -17,6 +19,118 @@ var (
 	)
 )
 
+// CardNotDrawnError occurs when a card is returned which wasn't drawn.
+type CardNotDrawnError struct {
+	Card InvaderCard
+}
+
+func (err CardNotDrawnError) Error() string {
+	return fmt.Sprintf("%s has not been drawn", err.Card)
+}
+
+// Is makes CardNotDrawnError an ErrInvalidInvaderCard.
+func (err CardNotDrawnError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
+// NotReturnableError occurs when Fractured Days returns a card
+// which isn't within one stage of the top of the deck.
+type NotReturnableError struct {
+	Card InvaderCard
+	// TopStage is the stage of the top of the deck, 0 when it is empty.
+	TopStage int
+}
+
+func (err NotReturnableError) Error() string {
+	if err.TopStage == 0 {
+		return fmt.Sprintf(
+			"%s can't be returned to an empty invader deck",
+			err.Card,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s must be within one stage of the top (stage %d) to return",
+		err.Card,
+		err.TopStage,
+	)
+}
+
+// Is makes NotReturnableError an ErrInvaderCardNotReturnable.
+func (err NotReturnableError) Is(target error) bool {
+	return target == ErrInvaderCardNotReturnable
+}
+
+// DuplicateCardError occurs when a card is drawn more than once.
+type DuplicateCardError struct {
+	Card InvaderCard
+}
+
+func (err DuplicateCardError) Error() string {
+	return fmt.Sprintf("%s has already been drawn", err.Card)
+}
+
+// Is makes DuplicateCardError an ErrInvalidInvaderCard.
+func (err DuplicateCardError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
+// PlacedCardError occurs when a card is drawn
+// which adversary setup placed further down the deck.
+type PlacedCardError struct {
+	Card InvaderCard
+	// Position is the 1-based position of the card in the deck.
+	Position int
+}
+
+func (err PlacedCardError) Error() string {
+	return fmt.Sprintf(
+		"%s was placed as card %d of the invader deck",
+		err.Card,
+		err.Position,
+	)
+}
+
+// Is makes PlacedCardError an ErrInvalidInvaderCard.
+func (err PlacedCardError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
+// RemovedCardError occurs when a card is drawn
+// which adversary setup removed from the deck.
+type RemovedCardError struct {
+	Card InvaderCard
+}
+
+func (err RemovedCardError) Error() string {
+	return fmt.Sprintf("%s is not in the invader deck", err.Card)
+}
+
+// Is makes RemovedCardError an ErrInvalidInvaderCard.
+func (err RemovedCardError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
+// UnexpectedCardError occurs when the top of the deck is known
+// and a different card is drawn.
+type UnexpectedCardError struct {
+	Expected InvaderCard
+	Got      InvaderCard
+}
+
+func (err UnexpectedCardError) Error() string {
+	return fmt.Sprintf(
+		"%s is on top of the invader deck, not %s",
+		err.Expected,
+		err.Got,
+	)
+}
+
+// Is makes UnexpectedCardError an ErrInvalidInvaderCard.
+func (err UnexpectedCardError) Is(target error) bool {
+	return target == ErrInvalidInvaderCard
+}
+
 type InvaderDeck struct {
 	game *Game
 
@@ -25,6 +139,23 @@ type InvaderDeck struct {
 }
 
 func NewInvaderDeck(game *Game) *InvaderDeck {
+	deck, _ := TraceInvaderDeck(game)
+
+	return deck
+}
+
+// DeckModification is a single rule an adversary applied to the invader deck.
+type DeckModification struct {
+	Adversary Adversary
+	Level     int
+	Rule      string
+	Before    []InvaderCardInDeck
+	After     []InvaderCardInDeck
+}
+
+// TraceInvaderDeck initializes the invader deck the same as NewInvaderDeck
+// and records every rule the supporting and then leading adversary applied.
+func TraceInvaderDeck(game *Game) (*InvaderDeck, []DeckModification) {
 	initial := []InvaderCardInDeck{
 		{StageOneUnknown, false},
 		{StageOneUnknown, false},
@@ -40,13 +171,28 @@ func NewInvaderDeck(game *Game) *InvaderDeck {
 		{StageThreeUnknown, false},
 	}
 
-	mod, ok := modinvaderdec[game.SupportingAdversary]
-	if ok {
-		initial = mod(initial, game.SupportingAdversaryLevel)
-	}
-	mod, ok = modinvaderdec[game.LeadingAdversary]
-	if ok {
-		initial = mod(initial, game.LeadingAdversaryLevel)
+	trace := []DeckModification{}
+	for _, adv := range game.adversaries() {
+		for _, rule := range adv.DeckRules {
+			if adv.level < rule.Level {
+				continue
+			}
+
+			// The trace keeps its own copies since the deck changes during the game.
+			before := make([]InvaderCardInDeck, len(initial))
+			copy(before, initial)
+			initial = rule.Apply(initial)
+			after := make([]InvaderCardInDeck, len(initial))
+			copy(after, initial)
+
+			trace = append(trace, DeckModification{
+				adv.Name,
+				rule.Level,
+				rule.Text,
+				before,
+				after,
+			})
+		}
 	}
 
 	return &InvaderDeck{
@@ -54,7 +200,7 @@ func NewInvaderDeck(game *Game) *InvaderDeck {
 
 		Drawn:  []InvaderCardDrawn{},
 		InDeck: initial,
-	}
+	}, trace
 }
 
 func (deck *InvaderDeck) Draw(card InvaderCard) error {
@@ -63,7 +209,14 @@ func (deck *InvaderDeck) Draw(card InvaderCard) error {
 	}
 
 	if card.Stage != deck.InDeck[0].Stage {
-		return ErrInvalidInvaderCard
+		return StageMismatchError{deck.InDeck[0].Stage, card.Stage}
+	}
+	if top := deck.InDeck[0].InvaderCard; top.Terrain != UnknownTerrain &&
+		top != card {
+		return UnexpectedCardError{top, card}
+	}
+	if err := deck.checkPhysical(card, 1); err != nil {
+		return err
 	}
 
 	deck.Drawn = append(deck.Drawn, InvaderCardDrawn{card, false})
@@ -77,7 +230,12 @@ func (deck *InvaderDeck) Return(card InvaderCard) error {
 	for cix, c := range deck.Drawn {
 		if c.InvaderCard == card {
 			if !c.PastReturnable {
-				return ErrInvaderCardNotReturnable
+				top := 0
+				if len(deck.InDeck) != 0 {
+					top = deck.InDeck[0].Stage
+				}
+
+				return NotReturnableError{card, top}
 			}
 
 			mod := make([]InvaderCardDrawn, len(deck.Drawn[:cix]))
@@ -98,7 +256,7 @@ func (deck *InvaderDeck) Return(card InvaderCard) error {
 		}
 	}
 
-	return ErrInvalidInvaderCard
+	return CardNotDrawnError{card}
 }
 
 func (deck *InvaderDeck) IgnoreRisingInterest() {
@@ -114,10 +272,10 @@ func (deck *InvaderDeck) IgnoreRisingInterest() {
 func (deck *InvaderDeck) DistractHardworkingSettlers() {
 	s2ix, s3ix := -1, -1
 	for i := len(deck.InDeck) - 1; i >= 0; i-- {
-		if s2ix != -1 && deck.InDeck[i].Stage == 2 {
+		if s2ix == -1 && deck.InDeck[i].Stage == 2 {
 			s2ix = i
 		}
-		if s3ix != -1 && deck.InDeck[i].Stage == 3 {
+		if s3ix == -1 && deck.InDeck[i].Stage == 3 {
 			s3ix = i
 		}
 	}
@@ -136,13 +294,18 @@ func (deck *InvaderDeck) DistractHardworkingSettlers() {
 }
 
 func (deck *InvaderDeck) Entrenched(card InvaderCard) error {
-	if (deck.game.LeadingAdversary != Russia || deck.game.LeadingAdversaryLevel < 5) &&
-		(deck.game.SupportingAdversary != Russia || deck.game.SupportingAdversaryLevel < 5) {
+	if !deck.game.hasRule(EntrenchedRule) {
 		return ErrNotEntrenched
 	}
 
-	if card.Stage != 2 && card.Stage != 3 {
-		return ErrInvalidInvaderCard
+	if card.Stage < 2 {
+		return StageMismatchError{2, card.Stage}
+	}
+	if card.Stage > 3 {
+		return StageMismatchError{3, card.Stage}
+	}
+	if err := deck.checkPhysical(card, 0); err != nil {
+		return err
 	}
 
 	deck.Drawn = append(deck.Drawn, InvaderCardDrawn{card, false})
@@ -163,276 +326,206 @@ type InvaderCardDrawn struct {
 	PastReturnable bool
 }
 
-func (deck *InvaderDeck) setReturnable() {
-	rem := len(deck.InDeck) != 0
-	stg := -99
-	if rem {
-		stg = deck.InDeck[0].Stage
-	}
-	for i := range deck.Drawn {
-		c := deck.Drawn[i]
-		if c.Stage > stg {
-			deck.Drawn[i].PastReturnable = rem && (c.Stage-stg <= 1)
-		} else {
-			deck.Drawn[i].PastReturnable = rem && (stg-c.Stage <= 1)
-		}
-	}
-}
+// The suffix marking a card as SpeciallyPlaced or PastReturnable.
+const markedNotation = "*"
 
-// TODO: what a mess.
-var modinvaderdec = map[Adversary]func(deck []InvaderCardInDeck, lvl int) []InvaderCardInDeck{
-	BrandenburgPrussia: func(deck []InvaderCardInDeck, lvl int) []InvaderCardInDeck {
-		if lvl >= 2 {
-			// Put 1 of the Stage III cards between Stage I and Stage II.
-			s2ix, s3ix := -1, -1
-			for i, c := range deck {
-				if c.Stage == 2 && s2ix == -1 {
-					s2ix = i
-				}
-				if c.Stage == 3 {
-					s3ix = i
-				}
-			}
+// String formats the card using the compact notation,
+// suffixed with a * when it has been specially placed.
+func (card InvaderCardInDeck) String() string {
+	if card.SpeciallyPlaced {
+		return card.InvaderCard.String() + markedNotation
+	}
 
-			if s2ix > 0 && s3ix > 0 && s2ix < s3ix {
-				mod := make([]InvaderCardInDeck, len(deck[:s2ix]))
-				copy(mod, deck[:s2ix])
-				deck[s3ix].SpeciallyPlaced = true
-				mod = append(mod, deck[s3ix])         // nozero
-				mod = append(mod, deck[s2ix:s3ix]...) // nozero
-				mod = append(mod, deck[s3ix+1:]...)   // nozero
-				deck = mod
-			}
-		}
+	return card.InvaderCard.String()
+}
 
-		if lvl >= 3 {
-			// Remove an additional Stage I Card.
-			for i, c := range deck {
-				if c.Stage == 1 {
-					deck = append(deck[:i], deck[i+1:]...)
+// MarshalText implements encoding.TextMarshaler using the compact notation.
+func (card InvaderCardInDeck) MarshalText() ([]byte, error) {
+	return []byte(card.String()), nil
+}
 
-					break
-				}
-			}
-		}
+// UnmarshalText implements encoding.TextUnmarshaler using the compact notation.
+func (card *InvaderCardInDeck) UnmarshalText(text []byte) error {
+	s, marked := strings.CutSuffix(string(text), markedNotation)
+	c, err := ParseInvaderCard(s)
+	if err != nil {
+		return err
+	}
+	*card = InvaderCardInDeck{c, marked}
 
-		if lvl >= 4 {
-			// Remove an additional Stage II Card.
-			for i, c := range deck {
-				if c.Stage == 2 {
-					deck = append(deck[:i], deck[i+1:]...)
+	return nil
+}
 
-					break
-				}
-			}
-		}
+// String formats the card using the compact notation,
+// suffixed with a * when it can be returned by Fractured Days.
+func (card InvaderCardDrawn) String() string {
+	if card.PastReturnable {
+		return card.InvaderCard.String() + markedNotation
+	}
 
-		if lvl >= 5 {
-			// Remove an additional Stage I Card.
-			for i, c := range deck {
-				if c.Stage == 1 {
-					deck = append(deck[:i], deck[i+1:]...)
+	return card.InvaderCard.String()
+}
 
-					break
-				}
-			}
-		}
+// MarshalText implements encoding.TextMarshaler using the compact notation.
+func (card InvaderCardDrawn) MarshalText() ([]byte, error) {
+	return []byte(card.String()), nil
+}
 
-		if lvl >= 6 {
-			// Remove all Stage I Cards.
-			for true {
-				s1ix := -1
-				for i, c := range deck {
-					if c.Stage == 1 {
-						s1ix = i
+// UnmarshalText implements encoding.TextUnmarshaler using the compact notation.
+func (card *InvaderCardDrawn) UnmarshalText(text []byte) error {
+	s, marked := strings.CutSuffix(string(text), markedNotation)
+	c, err := ParseInvaderCard(s)
+	if err != nil {
+		return err
+	}
+	*card = InvaderCardDrawn{c, marked}
 
-						break
-					}
-				}
+	return nil
+}
 
-				if s1ix != -1 {
-					deck = append(deck[:s1ix], deck[s1ix+1:]...)
+// The separator between the drawn cards and the cards in the deck.
+const deckNotation = "|"
+
+// String formats the deck as the drawn cards (oldest first),
+// a |, and then the cards in the deck (top first).
+// e.g. "1J* 1W* | 1? 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?".
+func (deck *InvaderDeck) String() string {
+	var sb strings.Builder
+	for _, c := range deck.Drawn {
+		sb.WriteString(c.String())
+		sb.WriteString(" ")
+	}
+	sb.WriteString(deckNotation)
+	for _, c := range deck.InDeck {
+		sb.WriteString(" ")
+		sb.WriteString(c.String())
+	}
 
-					continue
-				}
+	return sb.String()
+}
 
-				break
-			}
-		}
+// MarshalText implements encoding.TextMarshaler using the deck notation.
+func (deck *InvaderDeck) MarshalText() ([]byte, error) {
+	return []byte(deck.String()), nil
+}
 
-		return deck
-	},
-	HabsburgLivestock: func(deck []InvaderCardInDeck, lvl int) []InvaderCardInDeck {
-		if lvl >= 3 {
-			// Remove 1 additional Stage I Card.
-			for i, c := range deck {
-				if c.Stage == 1 {
-					deck = append(deck[:i], deck[i+1:]...)
+// UnmarshalText implements encoding.TextUnmarshaler using the deck notation.
+// The game of the deck is left unchanged.
+func (deck *InvaderDeck) UnmarshalText(text []byte) error {
+	drawn, indeck, ok := strings.Cut(string(text), deckNotation)
+	if !ok {
+		return fmt.Errorf(
+			"%w: %q has no %s between the drawn and in deck cards",
+			ErrInvalidInvaderCard,
+			text,
+			deckNotation,
+		)
+	}
 
-					break
-				}
-			}
+	deck.Drawn = make([]InvaderCardDrawn, 0, len(strings.Fields(drawn)))
+	for _, s := range strings.Fields(drawn) {
+		var c InvaderCardDrawn
+		if err := c.UnmarshalText([]byte(s)); err != nil {
+			return err
 		}
+		deck.Drawn = append(deck.Drawn, c)
+	}
 
-		return deck
-	},
-	HabsburgMines: func(deck []InvaderCardInDeck, lvl int) []InvaderCardInDeck {
-		if lvl >= 4 {
-			// Place the 'Salt Deposits' card in place of the 2nd Stage II card.
-			s2 := 0
-			for cix, c := range deck {
-				if c.Stage == 2 {
-					s2++
-					if s2 == 2 {
-						mod := make([]InvaderCardInDeck, len(deck[:cix]))
-						copy(mod, deck[:cix])
-						mod = append( // nozero
-							mod,
-							InvaderCardInDeck{StageTwoSaltDeposits, true},
-						)
-						mod = append( // nozero
-							mod,
-							deck[cix+1:]...)
-						deck = mod
-
-						break
-					}
-				}
-			}
+	deck.InDeck = make([]InvaderCardInDeck, 0, len(strings.Fields(indeck)))
+	for _, s := range strings.Fields(indeck) {
+		var c InvaderCardInDeck
+		if err := c.UnmarshalText([]byte(s)); err != nil {
+			return err
 		}
+		deck.InDeck = append(deck.InDeck, c)
+	}
 
-		return deck
-	},
-	Russia: func(deck []InvaderCardInDeck, lvl int) []InvaderCardInDeck {
-		if lvl >= 4 {
-			// Put 1 Stage III Card after each Stage II Card.
-			os2ix := -1
-			for true {
-				s3ix := -1
-				for i, c := range deck {
-					if c.Stage == 3 {
-						s3ix = i
-					}
-				}
+	return nil
+}
 
-				s2ix := -1
-				for i, c := range deck {
-					if c.Stage == 2 && i > os2ix {
-						s2ix = i
+// checkPhysical checks that the card is one of the physical cards
+// and that the card isn't anywhere else in the game.
+// The first skip cards in the deck aren't checked.
+func (deck *InvaderDeck) checkPhysical(card InvaderCard, skip int) error {
+	if card.Terrain == UnknownTerrain {
+		return nil
+	}
+	if !isPhysical(card) {
+		return UnknownCardError{card}
+	}
 
-						break
-					}
-				}
+	for _, c := range deck.Drawn {
+		if c.InvaderCard == card {
+			return DuplicateCardError{card}
+		}
+	}
+	for i := skip; i < len(deck.InDeck); i++ {
+		if deck.InDeck[i].InvaderCard == card {
+			return PlacedCardError{card, i + 1}
+		}
+	}
 
-				if s2ix != -1 && s3ix != -1 {
-					os2ix = s2ix
-					mod := make([]InvaderCardInDeck, len(deck[:s2ix+1]))
-					copy(mod, deck[:s2ix+1])
-					deck[s3ix].SpeciallyPlaced = true
-					mod = append(mod, deck[s3ix])           // nozero
-					mod = append(mod, deck[s2ix+1:s3ix]...) // nozero
-					mod = append(mod, deck[s3ix+1:]...)     // nozero
-					deck = mod
-
-					continue
-				}
+	return nil
+}
 
-				break
-			}
+func isPhysical(card InvaderCard) bool {
+	if card == StageTwoSaltDeposits {
+		return true
+	}
+	for _, c := range AllInvaderCards {
+		if c == card {
+			return true
 		}
+	}
 
-		return deck
-	},
-	Scotland: func(deck []InvaderCardInDeck, lvl int) []InvaderCardInDeck {
-		if lvl >= 2 {
-			// Place "Coastal Lands" as the 3rd Stage II card.
-			stage2 := 0
-			for cix, c := range deck {
-				if c.Stage == 2 {
-					stage2++
-					if stage2 == 3 {
-						mod := make([]InvaderCardInDeck, len(deck[:cix]))
-						copy(mod, deck[:cix])
-						mod = append( // nozero
-							mod,
-							InvaderCardInDeck{StageTwoCoastal, true},
-						)
-						mod = append( // nozero
-							mod,
-							deck[cix+1:]...)
-						deck = mod
-
-						break
-					}
-				}
-			}
+	return false
+}
 
-			// Move the two Stage II Cards above it up by one.
-			stage2 = 0
-			s2ix1, s2ix2 := -1, -1
-			for cix, card := range deck {
-				card := card
-				if card.InvaderCard == StageTwoCoastal {
-					break
-				}
+func (deck *InvaderDeck) clone() *InvaderDeck {
+	drawn := make([]InvaderCardDrawn, len(deck.Drawn))
+	copy(drawn, deck.Drawn)
+	indeck := make([]InvaderCardInDeck, len(deck.InDeck))
+	copy(indeck, deck.InDeck)
 
-				if card.Stage == 2 {
-					stage2++
-					switch stage2 {
-					case 1:
-						s2ix1 = cix
-					case 2:
-						s2ix2 = cix
-					}
-					if stage2 >= 2 {
-						break
-					}
-				}
-			}
+	return &InvaderDeck{
+		game: deck.game,
 
-			if s2ix1 >= 1 {
-				mod := make([]InvaderCardInDeck, len(deck[:s2ix1-1]))
-				copy(mod, deck[:s2ix1-1])
-				deck[s2ix1].SpeciallyPlaced = true
-				mod = append(mod, deck[s2ix1])       // nozero
-				mod = append(mod, deck[s2ix1-1])     // nozero
-				mod = append(mod, deck[s2ix1+1:]...) // nozero
-				deck = mod
-			}
-			if s2ix2 >= 2 {
-				mod := make([]InvaderCardInDeck, len(deck[:s2ix2-1]))
-				copy(mod, deck[:s2ix2-1])
-				deck[s2ix2].SpeciallyPlaced = true
-				mod = append(mod, deck[s2ix2])       // nozero
-				mod = append(mod, deck[s2ix2-1])     // nozero
-				mod = append(mod, deck[s2ix2+1:]...) // nozero
-				deck = mod
-			}
+		Drawn:  drawn,
+		InDeck: indeck,
+	}
+}
+
+func (deck *InvaderDeck) equal(other *InvaderDeck) bool {
+	if len(deck.Drawn) != len(other.Drawn) ||
+		len(deck.InDeck) != len(other.InDeck) {
+		return false
+	}
+	for i := range deck.Drawn {
+		if deck.Drawn[i] != other.Drawn[i] {
+			return false
+		}
+	}
+	for i := range deck.InDeck {
+		if deck.InDeck[i] != other.InDeck[i] {
+			return false
 		}
+	}
 
-		if lvl >= 4 {
-			// Replace the bottom Stage I Card with the bottom Stage III
-			s1ix, s3ix := -1, -1
-			for cix, c := range deck {
-				if c.Stage == 1 {
-					s1ix = cix
-				}
-				if c.Stage == 3 {
-					s3ix = cix
-				}
-			}
+	return true
+}
 
-			if s1ix >= 0 && s3ix >= 1 {
-				mod := make([]InvaderCardInDeck, len(deck[:s1ix]))
-				copy(mod, deck[:s1ix])
-				deck[s3ix].SpeciallyPlaced = true
-				mod = append(mod, deck[s3ix])           // nozero
-				mod = append(mod, deck[s1ix+1:s3ix]...) // nozero
-				mod = append(mod, deck[s3ix+1:]...)     // nozero
-				deck = mod
-			}
+func (deck *InvaderDeck) setReturnable() {
+	rem := len(deck.InDeck) != 0
+	stg := -99
+	if rem {
+		stg = deck.InDeck[0].Stage
+	}
+	for i := range deck.Drawn {
+		c := deck.Drawn[i]
+		if c.Stage > stg {
+			deck.Drawn[i].PastReturnable = rem && (c.Stage-stg <= 1)
+		} else {
+			deck.Drawn[i].PastReturnable = rem && (stg-c.Stage <= 1)
 		}
-
-		return deck
-	},
+	}
 }
@@ -1,8 +1,6 @@
 package domain_test
 
 import (
-	"bytes"
-	"strconv"
 	"testing"
 
 	"github.com/brycekbargar/spise/domain"
@@ -26,7 +24,104 @@ func TestInvaderDeck_Draw(t *testing.T) {
 
 		deck := domain.NewInvaderDeck(&domain.Game{})
 		err := deck.Draw(domain.StageThreeUnknown)
-		assert.ErrorIs(t, domain.ErrInvalidInvaderCard, err)
+		assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+		assert.Equal(t, domain.StageMismatchError{1, 3}, err)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		t.Parallel()
+
+		cases := []struct {
+			name  string
+			game  *domain.Game
+			drawn []domain.InvaderCard
+			card  domain.InvaderCard
+			err   error
+		}{
+			{
+				"NotPhysical",
+				&domain.Game{},
+				[]domain.InvaderCard{},
+				domain.InvaderCard{1, domain.CoastalLands, ""},
+				domain.UnknownCardError{
+					domain.InvaderCard{1, domain.CoastalLands, ""},
+				},
+			},
+			{
+				"Duplicate",
+				&domain.Game{},
+				[]domain.InvaderCard{domain.StageOneJungle},
+				domain.StageOneJungle,
+				domain.DuplicateCardError{domain.StageOneJungle},
+			},
+			{
+				"Placed",
+				&domain.Game{
+					LeadingAdversary:      domain.Scotland,
+					LeadingAdversaryLevel: 2,
+				},
+				[]domain.InvaderCard{
+					domain.StageOneJungle,
+					domain.StageOneWetland,
+				},
+				domain.StageTwoCoastal,
+				domain.PlacedCardError{domain.StageTwoCoastal, 4},
+			},
+			{
+				"Unexpected",
+				&domain.Game{
+					LeadingAdversary:      domain.HabsburgMines,
+					LeadingAdversaryLevel: 4,
+				},
+				[]domain.InvaderCard{
+					domain.StageOneJungle,
+					domain.StageOneWetland,
+					domain.StageOneSands,
+					domain.StageTwoJungle,
+				},
+				domain.StageTwoWetland,
+				domain.UnexpectedCardError{
+					domain.StageTwoSaltDeposits,
+					domain.StageTwoWetland,
+				},
+			},
+			{
+				"Expected",
+				&domain.Game{
+					LeadingAdversary:      domain.HabsburgMines,
+					LeadingAdversaryLevel: 4,
+				},
+				[]domain.InvaderCard{
+					domain.StageOneJungle,
+					domain.StageOneWetland,
+					domain.StageOneSands,
+					domain.StageTwoJungle,
+				},
+				domain.StageTwoSaltDeposits,
+				nil,
+			},
+		}
+
+		for _, tc := range cases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				deck := domain.NewInvaderDeck(tc.game)
+				for _, c := range tc.drawn {
+					assert.NilError(t, deck.Draw(c), c)
+				}
+
+				err := deck.Draw(tc.card)
+				if tc.err == nil {
+					assert.NilError(t, err)
+
+					return
+				}
+				assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+				assert.Equal(t, tc.err, err)
+			})
+		}
 	})
 
 	t.Run("FullDeck", func(t *testing.T) {
@@ -51,17 +146,17 @@ func TestInvaderDeck_Draw(t *testing.T) {
 			assert.NilError(t, err, dic)
 		}
 
-		assert.Equal(t, "111-2222-33333", deckToString(t, deck.Drawn))
+		assert.Equal(t, "1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3? |", deck.String())
 	})
 
 	t.Run("Returnable", func(t *testing.T) {
 		t.Parallel()
 
 		cases := []struct {
-			name    string
-			drawn   []domain.InvaderCard
-			inDeck  []domain.InvaderCard
-			discard string
+			name     string
+			drawn    []domain.InvaderCard
+			inDeck   []domain.InvaderCard
+			expected string
 		}{
 			{
 				"=Same",
@@ -70,7 +165,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 					domain.StageOneUnknown,
 					domain.StageOneUnknown,
 				},
-				"1*",
+				"1?* | 1?",
 			},
 			{
 				"+Stage",
@@ -79,7 +174,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 					domain.StageTwoUnknown,
 					domain.StageOneUnknown,
 				},
-				"2*",
+				"2?* | 1?",
 			},
 			{
 				"-Stage",
@@ -88,7 +183,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 					domain.StageTwoUnknown,
 					domain.StageThreeUnknown,
 				},
-				"2*",
+				"2?* | 3?",
 			},
 			{
 				"--Stage",
@@ -97,7 +192,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 					domain.StageOneUnknown,
 					domain.StageThreeUnknown,
 				},
-				"1",
+				"1? | 3?",
 			},
 			{
 				"++Stage",
@@ -106,7 +201,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 					domain.StageThreeUnknown,
 					domain.StageOneUnknown,
 				},
-				"3",
+				"3? | 1?",
 			},
 			{
 				"Empty",
@@ -114,7 +209,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 				[]domain.InvaderCard{
 					domain.StageThreeUnknown,
 				},
-				"3",
+				"3? |",
 			},
 			{
 				"Disparate",
@@ -128,7 +223,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 					domain.StageOneUnknown,
 					domain.StageThreeUnknown,
 				},
-				"2*-3*-2*-11",
+				"2?* 3?* 2?* 1? 1? | 3?",
 			},
 		}
 
@@ -159,7 +254,7 @@ func TestInvaderDeck_Draw(t *testing.T) {
 
 				err := deck.Draw(tc.inDeck[0])
 				assert.NilError(t, err)
-				assert.Equal(t, tc.discard, deckToString(t, deck.Drawn))
+				assert.Equal(t, tc.expected, deck.String())
 			})
 		}
 	})
@@ -263,6 +358,54 @@ func TestInvaderDeck_Entrenched(t *testing.T) {
 	})
 }
 
+func TestInvaderDeck_DistractHardworkingSettlers(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		deck     string
+		expected string
+	}{
+		{
+			"Base",
+			"| 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+			"| 1? 1? 1? 2? 2? 2? 3? 3? 3? 3?",
+		},
+		{
+			"Interleaved",
+			"| 1? 2? 3?* 2? 3?* 3?",
+			"| 1? 2? 3?* 3?*",
+		},
+		{
+			"StageTwoLast",
+			"1J | 1? 2? 3? 2?",
+			"1J | 1? 2?",
+		},
+		{
+			"NoStageThree",
+			"1J 1W 1S | 2? 2?",
+			"1J 1W 1S | 2?",
+		},
+		{
+			"Empty",
+			"1J 1W 1S |",
+			"1J 1W 1S |",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deck := domain.InvaderDeck{}
+			assert.NilError(t, deck.UnmarshalText([]byte(tc.deck)))
+			deck.DistractHardworkingSettlers()
+			assert.Equal(t, tc.expected, deck.String())
+		})
+	}
+}
+
 //nolint:exhaustruct
 func TestInvaderDeck_Return(t *testing.T) {
 	t.Parallel()
@@ -287,6 +430,11 @@ func TestInvaderDeck_Return(t *testing.T) {
 
 		err := deck.Return(domain.InvaderCard{Stage: 1})
 		assert.ErrorIs(t, err, domain.ErrInvaderCardNotReturnable)
+		assert.Equal(
+			t,
+			domain.NotReturnableError{domain.InvaderCard{Stage: 1}, 3},
+			err,
+		)
 	})
 
 	t.Run("Not Drawn", func(t *testing.T) {
@@ -302,6 +450,11 @@ func TestInvaderDeck_Return(t *testing.T) {
 
 		err := deck.Return(domain.StageOneJungle)
 		assert.ErrorIs(t, err, domain.ErrInvalidInvaderCard)
+		assert.Equal(
+			t,
+			domain.CardNotDrawnError{domain.StageOneJungle},
+			err,
+		)
 	})
 
 	t.Run("Returnable", func(t *testing.T) {
@@ -312,7 +465,7 @@ func TestInvaderDeck_Return(t *testing.T) {
 			drawn    []domain.InvaderCard
 			inDeck   []domain.InvaderCard
 			returned domain.InvaderCard
-			discard  string
+			expected string
 		}{
 			{
 				"Basic",
@@ -322,7 +475,7 @@ func TestInvaderDeck_Return(t *testing.T) {
 					domain.StageOneUnknown,
 				},
 				domain.StageOneJungle,
-				"1*",
+				"1?* | 1J",
 			},
 			{
 				"Many",
@@ -336,7 +489,7 @@ func TestInvaderDeck_Return(t *testing.T) {
 					domain.StageTwoUnknown,
 				},
 				domain.StageOneMountain,
-				"1*1*-2*-1*",
+				"1J* 1W* 2?* 1S* | 1M",
 			},
 			{
 				"TwoAway",
@@ -351,7 +504,7 @@ func TestInvaderDeck_Return(t *testing.T) {
 					domain.StageThreeUnknown,
 				},
 				domain.StageThreeMountainWetland,
-				"11-2*-3*-2*",
+				"1J 1W 2M* 3?* 2S* | 3MW",
 			},
 			{
 				"TwoAwayBridge",
@@ -366,7 +519,7 @@ func TestInvaderDeck_Return(t *testing.T) {
 					domain.StageThreeUnknown,
 				},
 				domain.StageTwoMountain,
-				"1*1*-3*3*-2*",
+				"1J* 1W* 3?* 3MW* 2S* | 2M",
 			},
 		}
 
@@ -402,64 +555,12 @@ func TestInvaderDeck_Return(t *testing.T) {
 				assert.NilError(t, err)
 
 				assert.Equal(t, tc.returned, deck.InDeck[0].InvaderCard)
-				assert.Equal(t, tc.discard, deckToString(t, deck.Drawn))
+				assert.Equal(t, tc.expected, deck.String())
 			})
 		}
 	})
 }
 
-func deckToString[IC any](t *testing.T, cards []IC) string {
-	t.Helper()
-
-	ostg := -1
-	var actual bytes.Buffer
-	for _, card := range cards {
-		// TODO: Fix this when generics suck less
-		var actcard domain.InvaderCard
-		switch dc := any(card).(type) {
-		case domain.InvaderCardInDeck:
-			actcard = dc.InvaderCard
-		case domain.InvaderCardDrawn:
-			actcard = dc.InvaderCard
-		}
-
-		sym := ""
-		var stg int
-		switch actcard {
-		case domain.StageTwoCoastal:
-			sym = "C"
-			stg = 2
-		case domain.StageTwoSaltDeposits:
-			sym = "S"
-			stg = 2
-		default:
-			if 1 > actcard.Stage || actcard.Stage > 3 {
-				t.Fatalf("invalidcard: %v", actcard)
-			}
-
-			sym = strconv.Itoa(actcard.Stage)
-			stg = actcard.Stage
-		}
-
-		idc, ok := any(card).(domain.InvaderCardInDeck)
-		if ok && idc.SpeciallyPlaced == true {
-			sym += "*"
-		}
-		dc, ok := any(card).(domain.InvaderCardDrawn)
-		if ok && dc.PastReturnable == true {
-			sym += "*"
-		}
-
-		if ostg != -1 && ostg != stg {
-			actual.WriteString("-")
-		}
-		actual.WriteString(sym)
-		ostg = stg
-	}
-
-	return actual.String()
-}
-
 //nolint:exhaustruct
 func TestInvaderDeck_NewInvaderDeck(t *testing.T) {
 	t.Parallel()
@@ -469,167 +570,167 @@ func TestInvaderDeck_NewInvaderDeck(t *testing.T) {
 		game    *domain.Game
 		initial string
 	}{
-		{"Base", &domain.Game{}, "111-2222-33333"},
+		{"Base", &domain.Game{}, "| 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?"},
 		{"BP2", &domain.Game{
 			LeadingAdversary:      domain.BrandenburgPrussia,
 			LeadingAdversaryLevel: 2,
-		}, "111-3*-2222-3333"},
+		}, "| 1? 1? 1? 3?* 2? 2? 2? 2? 3? 3? 3? 3?"},
 		{"BP3", &domain.Game{
 			LeadingAdversary:      domain.BrandenburgPrussia,
 			LeadingAdversaryLevel: 3,
-		}, "11-3*-2222-3333"},
+		}, "| 1? 1? 3?* 2? 2? 2? 2? 3? 3? 3? 3?"},
 		{"BP4", &domain.Game{
 			LeadingAdversary:      domain.BrandenburgPrussia,
 			LeadingAdversaryLevel: 4,
-		}, "11-3*-222-3333"},
+		}, "| 1? 1? 3?* 2? 2? 2? 3? 3? 3? 3?"},
 		{"BP5", &domain.Game{
 			LeadingAdversary:      domain.BrandenburgPrussia,
 			LeadingAdversaryLevel: 5,
-		}, "1-3*-222-3333"},
+		}, "| 1? 3?* 2? 2? 2? 3? 3? 3? 3?"},
 		{"BP6", &domain.Game{
 			LeadingAdversary:      domain.BrandenburgPrussia,
 			LeadingAdversaryLevel: 6,
-		}, "3*-222-3333"},
+		}, "| 3?* 2? 2? 2? 3? 3? 3? 3?"},
 		{"HLC3", &domain.Game{
 			LeadingAdversary:      domain.HabsburgLivestock,
 			LeadingAdversaryLevel: 3,
-		}, "11-2222-33333"},
+		}, "| 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?"},
 		{"HME4", &domain.Game{
 			LeadingAdversary:      domain.HabsburgMines,
 			LeadingAdversaryLevel: 4,
-		}, "111-2S*22-33333"},
+		}, "| 1? 1? 1? 2? 2SALT* 2? 2? 3? 3? 3? 3? 3?"},
 		{"R4", &domain.Game{
 			LeadingAdversary:      domain.Russia,
 			LeadingAdversaryLevel: 4,
-		}, "111-2-3*-2-3*-2-3*-2-3*3"},
+		}, "| 1? 1? 1? 2? 3?* 2? 3?* 2? 3?* 2? 3?* 3?"},
 		{"S2", &domain.Game{
 			LeadingAdversary:      domain.Scotland,
 			LeadingAdversaryLevel: 2,
-		}, "11-2*2*-1-C*2-33333"},
+		}, "| 1? 1? 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?"},
 		{"S4", &domain.Game{
 			LeadingAdversary:      domain.Scotland,
 			LeadingAdversaryLevel: 4,
-		}, "11-2*2*-3*-C*2-3333"},
+		}, "| 1? 1? 2?* 2?* 3?* 2C* 2? 3? 3? 3? 3?"},
 		{"BP5HLC3", &domain.Game{
 			LeadingAdversary:         domain.BrandenburgPrussia,
 			LeadingAdversaryLevel:    5,
 			SupportingAdversary:      domain.HabsburgLivestock,
 			SupportingAdversaryLevel: 3,
-		}, "3*-222-3333"},
+		}, "| 3?* 2? 2? 2? 3? 3? 3? 3?"},
 		{"BP5HME4", &domain.Game{
 			LeadingAdversary:         domain.BrandenburgPrussia,
 			LeadingAdversaryLevel:    5,
 			SupportingAdversary:      domain.HabsburgMines,
 			SupportingAdversaryLevel: 4,
-		}, "1-3*-S*22-3333"},
+		}, "| 1? 3?* 2SALT* 2? 2? 3? 3? 3? 3?"},
 		{"BP5R4", &domain.Game{
 			LeadingAdversary:         domain.BrandenburgPrussia,
 			LeadingAdversaryLevel:    5,
 			SupportingAdversary:      domain.Russia,
 			SupportingAdversaryLevel: 4,
-		}, "1-3*3*-2-3*-2-3*-2-3*"},
+		}, "| 1? 3?* 3?* 2? 3?* 2? 3?* 2? 3?*"},
 		{"BP5S4", &domain.Game{
 			LeadingAdversary:         domain.BrandenburgPrussia,
 			LeadingAdversaryLevel:    5,
 			SupportingAdversary:      domain.Scotland,
 			SupportingAdversaryLevel: 4,
-		}, "3*-2*-3*-C*2-333"},
+		}, "| 3?* 2?* 3?* 2C* 2? 3? 3? 3?"},
 		{"HLC3BP5", &domain.Game{
 			LeadingAdversary:         domain.HabsburgLivestock,
 			LeadingAdversaryLevel:    3,
 			SupportingAdversary:      domain.BrandenburgPrussia,
 			SupportingAdversaryLevel: 5,
-		}, "3*-222-3333"},
+		}, "| 3?* 2? 2? 2? 3? 3? 3? 3?"},
 		{"HLC3HME4", &domain.Game{
 			LeadingAdversary:         domain.HabsburgLivestock,
 			LeadingAdversaryLevel:    3,
 			SupportingAdversary:      domain.HabsburgMines,
 			SupportingAdversaryLevel: 4,
-		}, "11-2S*22-33333"},
+		}, "| 1? 1? 2? 2SALT* 2? 2? 3? 3? 3? 3? 3?"},
 		{"HLC3R4", &domain.Game{
 			LeadingAdversary:         domain.HabsburgLivestock,
 			LeadingAdversaryLevel:    3,
 			SupportingAdversary:      domain.Russia,
 			SupportingAdversaryLevel: 4,
-		}, "11-2-3*-2-3*-2-3*-2-3*3"},
+		}, "| 1? 1? 2? 3?* 2? 3?* 2? 3?* 2? 3?* 3?"},
 		{"HLC3S4", &domain.Game{
 			LeadingAdversary:         domain.HabsburgLivestock,
 			LeadingAdversaryLevel:    3,
 			SupportingAdversary:      domain.Scotland,
 			SupportingAdversaryLevel: 4,
-		}, "1-2*2*-3*-C*2-3333"},
+		}, "| 1? 2?* 2?* 3?* 2C* 2? 3? 3? 3? 3?"},
 		{"HME4BP5", &domain.Game{
 			LeadingAdversary:         domain.HabsburgMines,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.BrandenburgPrussia,
 			SupportingAdversaryLevel: 5,
-		}, "1-3*-2S*2-3333"},
+		}, "| 1? 3?* 2? 2SALT* 2? 3? 3? 3? 3?"},
 		{"HME4HLC3", &domain.Game{
 			LeadingAdversary:         domain.HabsburgMines,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.HabsburgLivestock,
 			SupportingAdversaryLevel: 3,
-		}, "11-2S*22-33333"},
+		}, "| 1? 1? 2? 2SALT* 2? 2? 3? 3? 3? 3? 3?"},
 		{"HME4R4", &domain.Game{
 			LeadingAdversary:         domain.HabsburgMines,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.Russia,
 			SupportingAdversaryLevel: 4,
-		}, "111-2-3*-S*-3*-2-3*-2-3*3"},
+		}, "| 1? 1? 1? 2? 3?* 2SALT* 3?* 2? 3?* 2? 3?* 3?"},
 		{"HME4S4", &domain.Game{
 			LeadingAdversary:         domain.HabsburgMines,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.Scotland,
 			SupportingAdversaryLevel: 4,
-		}, "11-2*S*-3*-C*2-3333"},
+		}, "| 1? 1? 2?* 2SALT* 3?* 2C* 2? 3? 3? 3? 3?"},
 		{"R4BP5", &domain.Game{
 			LeadingAdversary:         domain.Russia,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.BrandenburgPrussia,
 			SupportingAdversaryLevel: 5,
-		}, "1-3*-2-3*-2-3*-2-3*3"},
+		}, "| 1? 3?* 2? 3?* 2? 3?* 2? 3?* 3?"},
 		{"R4HLC3", &domain.Game{
 			LeadingAdversary:         domain.Russia,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.HabsburgLivestock,
 			SupportingAdversaryLevel: 3,
-		}, "11-2-3*-2-3*-2-3*-2-3*3"},
+		}, "| 1? 1? 2? 3?* 2? 3?* 2? 3?* 2? 3?* 3?"},
 		{"R4HME4", &domain.Game{
 			LeadingAdversary:         domain.Russia,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.HabsburgMines,
 			SupportingAdversaryLevel: 4,
-		}, "111-2-3*-S*-3*-2-3*-2-3*3"},
+		}, "| 1? 1? 1? 2? 3?* 2SALT* 3?* 2? 3?* 2? 3?* 3?"},
 		{"R4S4", &domain.Game{
 			LeadingAdversary:         domain.Russia,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.Scotland,
 			SupportingAdversaryLevel: 4,
-		}, "11-2*-3*-2*-3*3*-C*-3*-2-3*"},
+		}, "| 1? 1? 2?* 3?* 2?* 3?* 3?* 2C* 3?* 2? 3?*"},
 		{"S4BP5", &domain.Game{
 			LeadingAdversary:         domain.Scotland,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.BrandenburgPrussia,
 			SupportingAdversaryLevel: 5,
-		}, "3*-2*2*-3*-C*-333"},
+		}, "| 3?* 2?* 2?* 3?* 2C* 3? 3? 3?"},
 		{"S4HLC3", &domain.Game{
 			LeadingAdversary:         domain.Scotland,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.HabsburgLivestock,
 			SupportingAdversaryLevel: 3,
-		}, "1-2*2*-3*-C*2-3333"},
+		}, "| 1? 2?* 2?* 3?* 2C* 2? 3? 3? 3? 3?"},
 		{"S4HME4", &domain.Game{
 			LeadingAdversary:         domain.Scotland,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.HabsburgMines,
 			SupportingAdversaryLevel: 4,
-		}, "11-2*S*-3*-C*2-3333"},
+		}, "| 1? 1? 2?* 2SALT* 3?* 2C* 2? 3? 3? 3? 3?"},
 		{"S4R4", &domain.Game{
 			LeadingAdversary:         domain.Scotland,
 			LeadingAdversaryLevel:    4,
 			SupportingAdversary:      domain.Russia,
 			SupportingAdversaryLevel: 4,
-		}, "11-2*-3*-2*-3*3*-C*-3*-2-3*"},
+		}, "| 1? 1? 2?* 3?* 2?* 3?* 3?* 2C* 3?* 2? 3?*"},
 	}
 
 	for _, tc := range cases {
@@ -638,7 +739,69 @@ func TestInvaderDeck_NewInvaderDeck(t *testing.T) {
 			t.Parallel()
 
 			deck := domain.NewInvaderDeck(tc.game)
-			assert.Equal(t, tc.initial, deckToString(t, deck.InDeck))
+			assert.Equal(t, tc.initial, deck.String())
 		})
 	}
 }
+
+//nolint:exhaustruct
+func TestInvaderDeck_TraceInvaderDeck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Base", func(t *testing.T) {
+		t.Parallel()
+
+		_, trace := domain.TraceInvaderDeck(&domain.Game{})
+		assert.Equal(t, 0, len(trace))
+	})
+
+	t.Run("Combined", func(t *testing.T) {
+		t.Parallel()
+
+		deck, trace := domain.TraceInvaderDeck(&domain.Game{
+			LeadingAdversary:         domain.Russia,
+			LeadingAdversaryLevel:    4,
+			SupportingAdversary:      domain.Scotland,
+			SupportingAdversaryLevel: 4,
+		})
+
+		expected := []struct {
+			adversary domain.Adversary
+			level     int
+			before    string
+			after     string
+		}{
+			{
+				domain.Scotland, 2,
+				"| 1? 1? 1? 2? 2? 2? 2? 3? 3? 3? 3? 3?",
+				"| 1? 1? 1? 2? 2? 2C* 2? 3? 3? 3? 3? 3?",
+			},
+			{
+				domain.Scotland, 2,
+				"| 1? 1? 1? 2? 2? 2C* 2? 3? 3? 3? 3? 3?",
+				"| 1? 1? 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+			},
+			{
+				domain.Scotland, 4,
+				"| 1? 1? 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+				"| 1? 1? 2?* 2?* 3?* 2C* 2? 3? 3? 3? 3?",
+			},
+			{
+				domain.Russia, 4,
+				"| 1? 1? 2?* 2?* 3?* 2C* 2? 3? 3? 3? 3?",
+				"| 1? 1? 2?* 3?* 2?* 3?* 3?* 2C* 3?* 2? 3?*",
+			},
+		}
+		assert.Equal(t, len(expected), len(trace))
+		for i, e := range expected {
+			assert.Equal(t, e.adversary, trace[i].Adversary)
+			assert.Equal(t, e.level, trace[i].Level)
+			assert.Assert(t, trace[i].Rule != "")
+			before := domain.InvaderDeck{InDeck: trace[i].Before}
+			assert.Equal(t, e.before, before.String())
+			after := domain.InvaderDeck{InDeck: trace[i].After}
+			assert.Equal(t, e.after, after.String())
+		}
+		assert.DeepEqual(t, trace[len(trace)-1].After, deck.InDeck)
+	})
+}
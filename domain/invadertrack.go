@@ -0,0 +1,137 @@
+package domain
+
+// InvaderTrack is where the drawn invader cards are on the invader board.
+// Each Invader Phase the cards in Ravage ravage, the cards in Build build,
+// and the newly drawn cards explore before every card advances one space.
+type InvaderTrack struct {
+	game *Game
+
+	// HighImmigration is England's extra Build space left of Ravage.
+	// It is nil when the tile isn't on the invader board.
+	HighImmigration []InvaderCard `json:"highImmigration"`
+	Ravage          []InvaderCard `json:"ravage"`
+	Build           []InvaderCard `json:"build"`
+	Explore         []InvaderCard `json:"explore"`
+	Discard         []InvaderCard `json:"discard"`
+}
+
+// NewInvaderTrack initializes a new track with no cards on it.
+// England 3+ adds the High Immigration tile.
+func NewInvaderTrack(game *Game) *InvaderTrack {
+	track := &InvaderTrack{
+		game: game,
+
+		Ravage:  []InvaderCard{},
+		Build:   []InvaderCard{},
+		Explore: []InvaderCard{},
+		Discard: []InvaderCard{},
+	}
+	if game.hasRule(HighImmigrationRule) {
+		track.HighImmigration = []InvaderCard{}
+	}
+
+	return track
+}
+
+// Advance moves every card one space at the end of the Invader Phase.
+// Explore cards move to Build, Build to Ravage, and Ravage to the discard
+// (or to High Immigration first when England has added the tile).
+func (track *InvaderTrack) Advance() {
+	if track.HighImmigration == nil {
+		track.Discard = append(track.Discard, track.Ravage...)
+	} else {
+		track.Discard = append(track.Discard, track.HighImmigration...)
+		track.HighImmigration = track.Ravage
+		track.removeHighImmigration()
+	}
+	track.Ravage = track.Build
+	track.Build = track.Explore
+	track.Explore = []InvaderCard{}
+}
+
+// Builds are the cards which build this turn.
+func (track InvaderTrack) Builds() []InvaderCard {
+	builds := make([]InvaderCard, 0, len(track.Build)+len(track.HighImmigration))
+	builds = append(builds, track.HighImmigration...)
+
+	return append(builds, track.Build...)
+}
+
+// NextBuilds are the cards which build next turn.
+// These are already on the track since new cards are explored first.
+func (track InvaderTrack) NextBuilds() []InvaderCard {
+	next := track.clone()
+	next.Advance()
+
+	return next.Builds()
+}
+
+// removeHighImmigration removes the tile for England 3
+// once a Stage II card slides onto it, England 4+ keep it all game.
+func (track *InvaderTrack) removeHighImmigration() {
+	if track.game.hasRule(PermanentHighImmigrationRule) {
+		return
+	}
+
+	for _, c := range track.HighImmigration {
+		if c.Stage >= 2 {
+			track.Discard = append(track.Discard, track.HighImmigration...)
+			track.HighImmigration = nil
+
+			return
+		}
+	}
+}
+
+// explore places a newly drawn card in the Explore space.
+func (track *InvaderTrack) explore(card InvaderCard) {
+	track.Explore = append(track.Explore, card)
+}
+
+// discard places a card directly into the discard.
+func (track *InvaderTrack) discard(card InvaderCard) {
+	track.Discard = append(track.Discard, card)
+}
+
+// remove takes the card off of the track if it is on it.
+func (track *InvaderTrack) remove(card InvaderCard) {
+	for _, space := range []*[]InvaderCard{
+		&track.HighImmigration,
+		&track.Ravage,
+		&track.Build,
+		&track.Explore,
+		&track.Discard,
+	} {
+		for i, c := range *space {
+			if c == card {
+				mod := make([]InvaderCard, 0, len(*space)-1)
+				mod = append(mod, (*space)[:i]...)
+				*space = append(mod, (*space)[i+1:]...)
+
+				return
+			}
+		}
+	}
+}
+
+func (track *InvaderTrack) clone() *InvaderTrack {
+	cp := func(cards []InvaderCard) []InvaderCard {
+		if cards == nil {
+			return nil
+		}
+		c := make([]InvaderCard, len(cards))
+		copy(c, cards)
+
+		return c
+	}
+
+	return &InvaderTrack{
+		game: track.game,
+
+		HighImmigration: cp(track.HighImmigration),
+		Ravage:          cp(track.Ravage),
+		Build:           cp(track.Build),
+		Explore:         cp(track.Explore),
+		Discard:         cp(track.Discard),
+	}
+}
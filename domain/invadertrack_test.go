@@ -0,0 +1,194 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestInvaderTrack_Advance(t *testing.T) {
+	t.Parallel()
+
+	track := domain.NewInvaderTrack(&domain.Game{})
+	track.Explore = []domain.InvaderCard{domain.StageOneJungle}
+	track.Advance()
+	assert.DeepEqual(t, []domain.InvaderCard{}, track.Ravage)
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneJungle}, track.Build)
+	assert.DeepEqual(t, []domain.InvaderCard{}, track.Explore)
+
+	track.Explore = []domain.InvaderCard{domain.StageOneWetland}
+	track.Advance()
+	track.Advance()
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneJungle}, track.Discard)
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneWetland}, track.Ravage)
+	assert.DeepEqual(t, []domain.InvaderCard{}, track.Build)
+}
+
+//nolint:exhaustruct
+func TestInitializedGame_InvaderTrack(t *testing.T) {
+	t.Parallel()
+
+	game := initGame(t, &domain.Game{
+		LeadingAdversary:      domain.Russia,
+		LeadingAdversaryLevel: 5,
+	})
+	assert.NilError(t, game.Draw(domain.StageOneJungle))
+	assert.NilError(t, game.Advance())
+	assert.NilError(t, game.Draw(domain.StageOneWetland))
+	assert.NilError(t, game.Advance())
+	assert.NilError(t, game.Draw(domain.StageOneSands))
+
+	track := game.InvaderTrack()
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneJungle}, track.Ravage)
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneWetland}, track.Build)
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneSands}, track.Explore)
+
+	// The track is read-only.
+	track.Ravage[0] = domain.StageOneMountain
+	assert.Equal(t, domain.StageOneJungle, game.InvaderTrack().Ravage[0])
+
+	// Entrenched cards go straight to the discard.
+	assert.NilError(t, game.Entrenched(domain.StageTwoWetland))
+	assert.DeepEqual(
+		t,
+		[]domain.InvaderCard{domain.StageTwoWetland},
+		game.InvaderTrack().Discard,
+	)
+
+	// Returned cards are taken off of the track,
+	// the card drawn in their place is discarded.
+	assert.NilError(t, game.Return(domain.StageTwoWetland))
+	assert.DeepEqual(
+		t,
+		[]domain.InvaderCard{domain.StageTwoUnknown},
+		game.InvaderTrack().Discard,
+	)
+
+	assert.NilError(t, game.Undo())
+	assert.NilError(t, game.Undo())
+	assert.NilError(t, game.Undo())
+	track = game.InvaderTrack()
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneJungle}, track.Ravage)
+	assert.DeepEqual(t, []domain.InvaderCard{domain.StageOneWetland}, track.Build)
+	assert.DeepEqual(t, []domain.InvaderCard{}, track.Explore)
+}
+
+//nolint:exhaustruct
+func TestInvaderTrack_HighImmigration(t *testing.T) {
+	t.Parallel()
+
+	advance := func(
+		track *domain.InvaderTrack,
+		cards ...domain.InvaderCard,
+	) {
+		track.Explore = cards
+		track.Advance()
+	}
+
+	t.Run("NoTile", func(t *testing.T) {
+		t.Parallel()
+
+		track := domain.NewInvaderTrack(&domain.Game{
+			LeadingAdversary:      domain.England,
+			LeadingAdversaryLevel: 2,
+		})
+		assert.Assert(t, track.HighImmigration == nil)
+
+		advance(track, domain.StageOneJungle)
+		advance(track, domain.StageOneWetland)
+		advance(track)
+		assert.Assert(t, track.HighImmigration == nil)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneJungle},
+			track.Discard,
+		)
+	})
+
+	t.Run("ExtraBuild", func(t *testing.T) {
+		t.Parallel()
+
+		track := domain.NewInvaderTrack(&domain.Game{
+			SupportingAdversary:      domain.England,
+			SupportingAdversaryLevel: 3,
+		})
+		advance(track, domain.StageOneJungle)
+		advance(track, domain.StageOneWetland)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneJungle},
+			track.NextBuilds(),
+		)
+
+		// Jungle builds in Build and then again in High Immigration.
+		advance(track, domain.StageOneSands)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneJungle, domain.StageOneSands},
+			track.Builds(),
+		)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneWetland},
+			track.NextBuilds(),
+		)
+		advance(track, domain.StageOneMountain)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneWetland, domain.StageOneMountain},
+			track.Builds(),
+		)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageOneJungle},
+			track.Discard,
+		)
+	})
+
+	t.Run("Removed", func(t *testing.T) {
+		t.Parallel()
+
+		track := domain.NewInvaderTrack(&domain.Game{
+			LeadingAdversary:      domain.England,
+			LeadingAdversaryLevel: 3,
+		})
+		advance(track, domain.StageTwoJungle)
+		advance(track)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{},
+			track.NextBuilds(),
+		)
+		advance(track)
+		assert.Assert(t, track.HighImmigration == nil)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageTwoJungle},
+			track.Discard,
+		)
+	})
+
+	t.Run("Kept", func(t *testing.T) {
+		t.Parallel()
+
+		track := domain.NewInvaderTrack(&domain.Game{
+			LeadingAdversary:      domain.England,
+			LeadingAdversaryLevel: 4,
+		})
+		advance(track, domain.StageTwoJungle)
+		advance(track)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageTwoJungle},
+			track.NextBuilds(),
+		)
+		advance(track)
+		assert.DeepEqual(
+			t,
+			[]domain.InvaderCard{domain.StageTwoJungle},
+			track.HighImmigration,
+		)
+	})
+}
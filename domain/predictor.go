@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownPredictor occurs when a game uses a predictor which isn't registered.
+var ErrUnknownPredictor = errors.New("unknown predictor")
+
+// The names of the built in predictors.
+const (
+	// UniformPredictorName predicts each stage from the revealed cards.
+	UniformPredictorName = "uniform"
+	// ExactPredictorName enumerates the unseen cards.
+	ExactPredictorName = "exact"
+	// MonteCarloPredictorName samples the unseen cards.
+	MonteCarloPredictorName = "montecarlo"
+)
+
+// Predictor predicts the terrains of the cards left in the invader deck.
+type Predictor interface {
+	// Predict the chance of each terrain for the card at the position
+	// of the invader deck, 0 being the next card to be drawn.
+	// Terrains which can't be on the card are left out.
+	Predict(position int) map[Terrain]float64
+}
+
+// PredictorStrategy creates a Predictor for a snapshot of a game.
+// The seed is for strategies which are random.
+type PredictorStrategy func(snapshot Snapshot, seed int64) Predictor
+
+// Snapshot is a copy of the state containers of a game.
+type Snapshot struct {
+	InvaderDeck     InvaderDeck
+	InvaderCardpool InvaderCardpool
+	InvaderTrack    InvaderTrack
+}
+
+// Snapshot is a copy of the state containers of the game.
+func (g *InitializedGame) Snapshot() Snapshot {
+	return Snapshot{
+		g.InvaderDeck(),
+		g.InvaderCardpool(),
+		g.InvaderTrack(),
+	}
+}
+
+var (
+	predictorsMu sync.RWMutex
+	predictors   = map[string]PredictorStrategy{
+		UniformPredictorName: func(snapshot Snapshot, _ int64) Predictor {
+			return NewUniformPredictor(snapshot)
+		},
+		ExactPredictorName: func(snapshot Snapshot, _ int64) Predictor {
+			return NewExactPredictor(
+				snapshot.InvaderDeck,
+				snapshot.InvaderCardpool,
+			)
+		},
+		MonteCarloPredictorName: func(snapshot Snapshot, seed int64) Predictor {
+			return NewMonteCarloPredictor(snapshot, MonteCarloSamples, seed)
+		},
+	}
+)
+
+// RegisterPredictor makes the strategy available to games by name.
+// Registering a name again replaces the previous strategy.
+func RegisterPredictor(name string, strategy PredictorStrategy) {
+	predictorsMu.Lock()
+	defer predictorsMu.Unlock()
+
+	predictors[name] = strategy
+}
+
+// PredictorNames are the names of every registered predictor, sorted.
+func PredictorNames() []string {
+	predictorsMu.RLock()
+	defer predictorsMu.RUnlock()
+
+	names := make([]string, 0, len(predictors))
+	for n := range predictors {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewPredictor creates the predictor chosen for the game.
+// Games without a predictor use the uniform predictor.
+func (g *InitializedGame) NewPredictor() (Predictor, error) {
+	name := g.Game.Predictor
+	if name == "" {
+		name = UniformPredictorName
+	}
+
+	predictorsMu.RLock()
+	strategy, ok := predictors[name]
+	predictorsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPredictor, name)
+	}
+
+	return strategy(g.Snapshot(), g.Seed), nil
+}
+
+// PredictStage predicts the next card of the stage with the game's predictor.
+// Stages without any cards left in the deck are predicted from the cardpool.
+func (g *InitializedGame) PredictStage(stage int) (map[Terrain]float64, error) {
+	for i, c := range g.invaderdeck.InDeck {
+		if c.Stage != stage {
+			continue
+		}
+
+		p, err := g.NewPredictor()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.Predict(i), nil
+	}
+
+	return g.invadercardpool.Predict(stage)
+}
+
+// UniformPredictor predicts each unknown card from the revealed cards
+// of its stage, this is the same as InvaderCardpool.Predict.
+type UniformPredictor struct {
+	indeck []InvaderCardInDeck
+	icp    InvaderCardpool
+}
+
+// NewUniformPredictor creates a uniform predictor for the snapshot.
+func NewUniformPredictor(snapshot Snapshot) *UniformPredictor {
+	return &UniformPredictor{
+		snapshot.InvaderDeck.InDeck,
+		snapshot.InvaderCardpool,
+	}
+}
+
+// Predict the chance of each terrain for the card at the position.
+func (up *UniformPredictor) Predict(position int) map[Terrain]float64 {
+	if position < 0 || position >= len(up.indeck) {
+		return map[Terrain]float64{}
+	}
+
+	card := up.indeck[position].InvaderCard
+	if card.Terrain != UnknownTerrain {
+		return cardTerrains(card)
+	}
+
+	pcts, err := up.icp.Predict(card.Stage)
+	if err != nil {
+		return map[Terrain]float64{}
+	}
+
+	return pcts
+}
+
+// MonteCarloSamples is how many decks the Monte Carlo predictor samples.
+const MonteCarloSamples = 10000
+
+// MonteCarloPredictor estimates the chances by shuffling the unseen cards
+// into the unknown cards of the deck many times.
+// The same seed always gives the same predictions.
+type MonteCarloPredictor struct {
+	samples int
+	counts  []map[Terrain]int
+}
+
+// NewMonteCarloPredictor samples the snapshot's deck at least once.
+func NewMonteCarloPredictor(
+	snapshot Snapshot,
+	samples int,
+	seed int64,
+) *MonteCarloPredictor {
+	if samples < 1 {
+		samples = 1
+	}
+	//nolint:gosec // the predictions don't need to be secure
+	rng := rand.New(rand.NewSource(seed))
+
+	counts := make([]map[Terrain]int, len(snapshot.InvaderDeck.InDeck))
+	for i := range counts {
+		counts[i] = make(map[Terrain]int)
+	}
+	for s := 0; s < samples; s++ {
+		for i, card := range DealInvaderDeck(
+			snapshot.InvaderDeck,
+			snapshot.InvaderCardpool,
+			rng,
+		) {
+			// There are more unknown cards than physical ones left.
+			if card.Terrain == UnknownTerrain {
+				continue
+			}
+			for t := range cardTerrains(card) {
+				counts[i][t]++
+			}
+		}
+	}
+
+	return &MonteCarloPredictor{samples, counts}
+}
+
+// Predict the chance of each terrain for the card at the position.
+func (mcp *MonteCarloPredictor) Predict(position int) map[Terrain]float64 {
+	pcts := make(map[Terrain]float64)
+	if position < 0 || position >= len(mcp.counts) {
+		return pcts
+	}
+
+	for t, n := range mcp.counts[position] {
+		pcts[t] = float64(n) / float64(mcp.samples)
+	}
+
+	return pcts
+}
+
+// cardTerrains is every terrain on a known card.
+func cardTerrains(card InvaderCard) map[Terrain]float64 {
+	pcts := map[Terrain]float64{card.Terrain: 1.0}
+	if card.Terrain2 != UnknownTerrain {
+		pcts[card.Terrain2] = 1.0
+	}
+
+	return pcts
+}
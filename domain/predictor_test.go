@@ -0,0 +1,128 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+// fixedPredictor always predicts the same terrain.
+type fixedPredictor struct {
+	terrain domain.Terrain
+}
+
+func (fp fixedPredictor) Predict(int) map[domain.Terrain]float64 {
+	return map[domain.Terrain]float64{fp.terrain: 1}
+}
+
+//nolint:exhaustruct
+func TestInitializedGame_NewPredictor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Strategies", func(t *testing.T) {
+		t.Parallel()
+
+		// Every strategy agrees on a game with no correlated cards.
+		for _, name := range []string{
+			"",
+			domain.UniformPredictorName,
+			domain.ExactPredictorName,
+			domain.MonteCarloPredictorName,
+		} {
+			game := initGame(t, &domain.Game{Predictor: name, Seed: 42})
+			assert.NilError(t, game.Draw(domain.StageOneJungle))
+
+			pred, err := game.NewPredictor()
+			assert.NilError(t, err)
+			for pos, c := range game.InvaderDeck().InDeck {
+				want, err := game.InvaderCardpool().Predict(c.Stage)
+				assert.NilError(t, err)
+
+				got := pred.Predict(pos)
+				assert.Equal(t, len(want), len(got), name)
+				for trn, p := range want {
+					assert.Assert(
+						t,
+						math.Abs(p-got[trn]) < 0.02,
+						"%s: %s at %d is %v, not %v",
+						name,
+						trn,
+						pos,
+						got[trn],
+						p,
+					)
+				}
+			}
+		}
+	})
+
+	t.Run("Known", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		assert.NilError(t, game.Draw(domain.StageOneWetland))
+		assert.NilError(t, game.Draw(domain.StageOneSands))
+		assert.NilError(t, game.Draw(domain.StageTwoJungle))
+		assert.NilError(t, game.Return(domain.StageOneSands))
+
+		pred, err := game.NewPredictor()
+		assert.NilError(t, err)
+		assert.DeepEqual(
+			t,
+			map[domain.Terrain]float64{domain.Sands: 1},
+			pred.Predict(0),
+		)
+		assert.DeepEqual(t, map[domain.Terrain]float64{}, pred.Predict(20))
+	})
+
+	t.Run("Seeded", func(t *testing.T) {
+		t.Parallel()
+
+		snapshot := initGame(t, &domain.Game{}).Snapshot()
+		first := domain.NewMonteCarloPredictor(snapshot, 100, 7)
+		second := domain.NewMonteCarloPredictor(snapshot, 100, 7)
+		for pos := 0; pos < 12; pos++ {
+			assert.DeepEqual(t, first.Predict(pos), second.Predict(pos))
+		}
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		t.Parallel()
+
+		domain.RegisterPredictor(
+			"always-wetland",
+			func(domain.Snapshot, int64) domain.Predictor {
+				return fixedPredictor{domain.Wetland}
+			},
+		)
+		assert.Assert(t, contains(domain.PredictorNames(), "always-wetland"))
+
+		game := initGame(t, &domain.Game{Predictor: "always-wetland"})
+		pcts, err := game.PredictStage(1)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, map[domain.Terrain]float64{domain.Wetland: 1}, pcts)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{Predictor: "psychic"})
+		_, err := game.NewPredictor()
+		assert.ErrorIs(t, err, domain.ErrUnknownPredictor)
+		_, err = game.PredictStage(1)
+		assert.ErrorIs(t, err, domain.ErrUnknownPredictor)
+	})
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,298 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	mapset "github.com/deckarep/golang-set/v2"
+)
+
+// SavedGameVersion is the current version of the saved game format.
+// Version 1 saved only the state containers,
+// version 2 saved the events which produced them,
+// version 3 also saves the state after the events to check the replay.
+const SavedGameVersion = 3
+
+// ErrUnsupportedSavedGame occurs when a saved game can't be loaded.
+var ErrUnsupportedSavedGame = errors.New("unsupported saved game")
+
+// savedGame is the versioned JSON representation of an InitializedGame.
+type savedGame struct {
+	Version                  int       `json:"version"`
+	LeadingAdversary         Adversary `json:"leadingAdversary"`
+	LeadingAdversaryLevel    int       `json:"leadingAdversaryLevel"`
+	SupportingAdversary      Adversary `json:"supportingAdversary"`
+	SupportingAdversaryLevel int       `json:"supportingAdversaryLevel"`
+	Predictor                string    `json:"predictor,omitempty"`
+	Seed                     int64     `json:"seed,omitempty"`
+	Virtual                  bool      `json:"virtual,omitempty"`
+
+	// Version 1 is the state, version 2+ is the state before the events.
+	InvaderDeck     *savedInvaderDeck     `json:"invaderDeck,omitempty"`
+	InvaderCardpool map[int][]InvaderCard `json:"invaderCardpool,omitempty"`
+
+	Events []savedEvent `json:"events,omitempty"`
+	Undone []savedEvent `json:"undone,omitempty"`
+
+	// State is the state after the events, since version 3.
+	State *savedState `json:"state,omitempty"`
+}
+
+type savedState struct {
+	InvaderDeck     *savedInvaderDeck     `json:"invaderDeck"`
+	InvaderCardpool map[int][]InvaderCard `json:"invaderCardpool"`
+}
+
+type savedInvaderDeck struct {
+	Drawn  []InvaderCardDrawn  `json:"drawn"`
+	InDeck []InvaderCardInDeck `json:"inDeck"`
+}
+
+type savedEvent struct {
+	Type string       `json:"type"`
+	Card *InvaderCard `json:"card,omitempty"`
+}
+
+// The type of each event in a saved game.
+const (
+	savedCardDrawn                     = "draw"
+	savedCardReturned                  = "return"
+	savedRisingInterestIgnored         = "ignore-rising-interest"
+	savedHardworkingSettlersDistracted = "distract-hardworking-settlers"
+	savedCardEntrenched                = "entrench"
+	savedInvadersAdvanced              = "advance"
+)
+
+// MarshalJSON saves the game and the events which changed its containers.
+func (g *InitializedGame) MarshalJSON() ([]byte, error) {
+	saved := savedGame{
+		Version:                  SavedGameVersion,
+		LeadingAdversary:         g.LeadingAdversary,
+		LeadingAdversaryLevel:    g.LeadingAdversaryLevel,
+		SupportingAdversary:      g.SupportingAdversary,
+		SupportingAdversaryLevel: g.SupportingAdversaryLevel,
+		Predictor:                g.Game.Predictor,
+		Seed:                     g.Seed,
+		Virtual:                  g.Virtual,
+		Events:                   saveEvents(g.events),
+		Undone:                   saveEvents(g.undone),
+	}
+	// Both states are saved so loading doesn't depend on the adversaries
+	// setting up and replaying the deck the same way they did when saved.
+	saved.InvaderDeck, saved.InvaderCardpool = saveState(g.base)
+	deck, cardpool := saveState(g.state())
+	saved.State = &savedState{deck, cardpool}
+
+	return json.Marshal(saved)
+}
+
+func saveState(
+	state gameState,
+) (*savedInvaderDeck, map[int][]InvaderCard) {
+	revealed := make(
+		map[int][]InvaderCard,
+		len(state.invadercardpool.Revealed),
+	)
+	for stg, cards := range state.invadercardpool.Revealed {
+		revealed[stg] = make([]InvaderCard, 0, cards.Cardinality())
+		// Keep the order stable between saves.
+		for _, c := range AllInvaderCards {
+			if cards.Contains(c) {
+				revealed[stg] = append(revealed[stg], c)
+			}
+		}
+	}
+
+	return &savedInvaderDeck{
+		Drawn:  state.invaderdeck.Drawn,
+		InDeck: state.invaderdeck.InDeck,
+	}, revealed
+}
+
+func saveEvents(events []Event) []savedEvent {
+	saved := make([]savedEvent, 0, len(events))
+	for _, e := range events {
+		switch e := e.(type) {
+		case CardDrawn:
+			saved = append(saved, savedEvent{savedCardDrawn, &e.Card})
+		case CardReturned:
+			saved = append(saved, savedEvent{savedCardReturned, &e.Card})
+		case RisingInterestIgnored:
+			saved = append(saved, savedEvent{savedRisingInterestIgnored, nil})
+		case HardworkingSettlersDistracted:
+			saved = append(
+				saved,
+				savedEvent{savedHardworkingSettlersDistracted, nil},
+			)
+		case CardEntrenched:
+			saved = append(saved, savedEvent{savedCardEntrenched, &e.Card})
+		case InvadersAdvanced:
+			saved = append(saved, savedEvent{savedInvadersAdvanced, nil})
+		}
+	}
+
+	return saved
+}
+
+// UnmarshalJSON loads a game saved by MarshalJSON.
+// The state containers are rebuilt by replaying the saved events,
+// it is an error if they don't match the saved state.
+func (g *InitializedGame) UnmarshalJSON(data []byte) error {
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	if saved.Version < 1 || saved.Version > SavedGameVersion {
+		return fmt.Errorf(
+			"%w: version %d, expected at most %d",
+			ErrUnsupportedSavedGame,
+			saved.Version,
+			SavedGameVersion,
+		)
+	}
+
+	loaded, err := (&Game{
+		LeadingAdversary:         saved.LeadingAdversary,
+		LeadingAdversaryLevel:    saved.LeadingAdversaryLevel,
+		SupportingAdversary:      saved.SupportingAdversary,
+		SupportingAdversaryLevel: saved.SupportingAdversaryLevel,
+		Predictor:                saved.Predictor,
+		Seed:                     saved.Seed,
+		Virtual:                  saved.Virtual,
+	}).Init()
+	if err != nil {
+		return err
+	}
+
+	if saved.InvaderDeck != nil || saved.InvaderCardpool != nil {
+		base, err := loadState(
+			loaded.Game,
+			saved.InvaderDeck,
+			saved.InvaderCardpool,
+		)
+		if err != nil {
+			return err
+		}
+		loaded.base = base
+	}
+
+	events, err := loadEvents(saved.Events)
+	if err != nil {
+		return err
+	}
+	if err := loaded.replay(events); err != nil {
+		return fmt.Errorf("%w: %w", ErrUnsupportedSavedGame, err)
+	}
+	if saved.State != nil {
+		state, err := loadState(
+			loaded.Game,
+			saved.State.InvaderDeck,
+			saved.State.InvaderCardpool,
+		)
+		if err != nil {
+			return err
+		}
+		if !state.invaderdeck.equal(loaded.invaderdeck) ||
+			!state.invadercardpool.equal(loaded.invadercardpool) {
+			return fmt.Errorf(
+				"%w: replaying the events doesn't give the saved state",
+				ErrUnsupportedSavedGame,
+			)
+		}
+	}
+	loaded.undone, err = loadEvents(saved.Undone)
+	if err != nil {
+		return err
+	}
+
+	*g = *loaded
+
+	return nil
+}
+
+func loadState(
+	game *Game,
+	saveddeck *savedInvaderDeck,
+	savedcardpool map[int][]InvaderCard,
+) (gameState, error) {
+	if saveddeck == nil {
+		saveddeck = &savedInvaderDeck{}
+	}
+
+	icp := &InvaderCardpool{
+		Revealed: map[int]mapset.Set[InvaderCard]{
+			1: mapset.NewSetWithSize[InvaderCard](4),
+			2: mapset.NewSetWithSize[InvaderCard](5),
+			3: mapset.NewSetWithSize[InvaderCard](6),
+		},
+	}
+	for stg, cards := range savedcardpool {
+		for _, c := range cards {
+			if c.Stage != stg {
+				return gameState{}, fmt.Errorf(
+					"%w: %s was revealed as a stage %d card",
+					ErrUnsupportedSavedGame,
+					c,
+					stg,
+				)
+			}
+			if err := icp.Reveal(c); err != nil {
+				return gameState{}, fmt.Errorf(
+					"%w: %w",
+					ErrUnsupportedSavedGame,
+					err,
+				)
+			}
+		}
+	}
+
+	deck := &InvaderDeck{
+		game: game,
+
+		Drawn:  saveddeck.Drawn,
+		InDeck: saveddeck.InDeck,
+	}
+	if deck.Drawn == nil {
+		deck.Drawn = []InvaderCardDrawn{}
+	}
+	if deck.InDeck == nil {
+		deck.InDeck = []InvaderCardInDeck{}
+	}
+
+	// The track is always empty before the first event.
+	return gameState{icp, deck, NewInvaderTrack(game)}, nil
+}
+
+func loadEvents(saved []savedEvent) ([]Event, error) {
+	events := make([]Event, 0, len(saved))
+	for _, se := range saved {
+		card := InvaderCard{}
+		if se.Card != nil {
+			card = *se.Card
+		}
+
+		switch se.Type {
+		case savedCardDrawn:
+			events = append(events, CardDrawn{card})
+		case savedCardReturned:
+			events = append(events, CardReturned{card})
+		case savedRisingInterestIgnored:
+			events = append(events, RisingInterestIgnored{})
+		case savedHardworkingSettlersDistracted:
+			events = append(events, HardworkingSettlersDistracted{})
+		case savedCardEntrenched:
+			events = append(events, CardEntrenched{card})
+		case savedInvadersAdvanced:
+			events = append(events, InvadersAdvanced{})
+		default:
+			return nil, fmt.Errorf(
+				"%w: %q is not an event",
+				ErrUnsupportedSavedGame,
+				se.Type,
+			)
+		}
+	}
+
+	return events, nil
+}
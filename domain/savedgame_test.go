@@ -0,0 +1,235 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestInitializedGame_Saved(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:         domain.Russia,
+			LeadingAdversaryLevel:    5,
+			SupportingAdversary:      domain.Scotland,
+			SupportingAdversaryLevel: 2,
+			Predictor:                domain.ExactPredictorName,
+			Seed:                     3,
+		})
+		for _, c := range []domain.InvaderCard{
+			domain.StageOneJungle,
+			domain.StageOneWetland,
+			domain.StageTwoMountain,
+		} {
+			assert.NilError(t, game.Draw(c))
+		}
+		assert.NilError(t, game.Undo())
+		assert.NilError(t, game.Draw(domain.StageTwoMountain))
+		assert.NilError(t, game.Advance())
+		assert.NilError(t, game.Draw(domain.StageThreeJungleWetland))
+		assert.NilError(t, game.Undo())
+
+		saved, err := json.Marshal(game)
+		assert.NilError(t, err)
+
+		loaded := &domain.InitializedGame{}
+		assert.NilError(t, json.Unmarshal(saved, loaded))
+
+		assert.DeepEqual(t, *game.Game, *loaded.Game)
+		assert.Equal(t, deckString(game), deckString(loaded))
+		assert.DeepEqual(
+			t,
+			game.InvaderTrack().Build,
+			loaded.InvaderTrack().Build,
+		)
+		for stg := 1; stg <= 3; stg++ {
+			assert.Assert(t, game.InvaderCardpool().Revealed[stg].Equal(
+				loaded.InvaderCardpool().Revealed[stg],
+			))
+		}
+
+		// The loaded game continues exactly as the original.
+		for _, g := range []*domain.InitializedGame{game, loaded} {
+			assert.NilError(t, g.Redo())
+			assert.NilError(t, g.Entrenched(domain.StageThreeJungleSands))
+		}
+		assert.Equal(t, deckString(game), deckString(loaded))
+		for stg := 1; stg <= 3; stg++ {
+			op, err := game.InvaderCardpool().Predict(stg)
+			assert.NilError(t, err)
+			lp, err := loaded.InvaderCardpool().Predict(stg)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, op, lp)
+		}
+
+		resaved, err := json.Marshal(loaded)
+		assert.NilError(t, err)
+		saved, err = json.Marshal(game)
+		assert.NilError(t, err)
+		assert.Equal(t, string(saved), string(resaved))
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		t.Parallel()
+
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:      domain.Scotland,
+			LeadingAdversaryLevel: 2,
+		})
+		assert.NilError(t, game.Draw(domain.StageOneJungle))
+		assert.NilError(t, game.IgnoreRisingInterest())
+		assert.NilError(t, game.Draw(domain.StageTwoSands))
+		assert.NilError(t, game.Undo())
+
+		saved, err := json.Marshal(game)
+		assert.NilError(t, err)
+		assert.Equal(t, `{"version":3,`+
+			`"leadingAdversary":"scotland","leadingAdversaryLevel":2,`+
+			`"supportingAdversary":"","supportingAdversaryLevel":0,`+
+			`"invaderDeck":{"drawn":[],`+
+			`"inDeck":["1?","1?","2?*","2?*","1?","2C*","2?",`+
+			`"3?","3?","3?","3?","3?"]},`+
+			`"invaderCardpool":{"1":[],"2":["2C"],"3":[]},`+
+			`"events":[{"type":"draw","card":"1J"},`+
+			`{"type":"ignore-rising-interest"}],`+
+			`"undone":[{"type":"draw","card":"2S"}],`+
+			`"state":{"invaderDeck":{"drawn":["1J*"],`+
+			`"inDeck":["2?*","2?*","1?","2C*","2?",`+
+			`"3?","3?","3?","3?","3?"]},`+
+			`"invaderCardpool":{"1":["1J"],"2":["2C"],"3":[]}}}`,
+			string(saved))
+	})
+
+	t.Run("Version1", func(t *testing.T) {
+		t.Parallel()
+
+		saved := `{"version":1,` +
+			`"leadingAdversary":"scotland","leadingAdversaryLevel":2,` +
+			`"supportingAdversary":"","supportingAdversaryLevel":0,` +
+			`"invaderDeck":{"drawn":["1J*"],` +
+			`"inDeck":["1?","2?*","2?*","1?","2C*","2?",` +
+			`"3?","3?","3?","3?","3?"]},` +
+			`"invaderCardpool":{"1":["1J"],"2":["2C"],"3":[]}}`
+
+		game := &domain.InitializedGame{}
+		assert.NilError(t, json.Unmarshal([]byte(saved), game))
+		assert.Equal(
+			t,
+			"1J* | 1? 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+			deckString(game),
+		)
+
+		// The loaded state can't be undone but anything after it can.
+		assert.ErrorIs(t, game.Undo(), domain.ErrNothingToUndo)
+		assert.NilError(t, game.Draw(domain.StageOneWetland))
+		assert.NilError(t, game.Undo())
+		assert.Equal(
+			t,
+			"1J* | 1? 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+			deckString(game),
+		)
+
+		// And it is saved for the next time the game is loaded.
+		resaved, err := json.Marshal(game)
+		assert.NilError(t, err)
+		reloaded := &domain.InitializedGame{}
+		assert.NilError(t, json.Unmarshal(resaved, reloaded))
+		assert.NilError(t, reloaded.Redo())
+		assert.Equal(
+			t,
+			"1J* 1W* | 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+			deckString(reloaded),
+		)
+	})
+
+	t.Run("Version2", func(t *testing.T) {
+		t.Parallel()
+
+		// The base is only saved when it isn't a new game.
+		saved := `{"version":2,` +
+			`"leadingAdversary":"scotland","leadingAdversaryLevel":2,` +
+			`"supportingAdversary":"","supportingAdversaryLevel":0,` +
+			`"events":[{"type":"draw","card":"1J"},` +
+			`{"type":"ignore-rising-interest"}],` +
+			`"undone":[{"type":"draw","card":"2S"}]}`
+
+		game := &domain.InitializedGame{}
+		assert.NilError(t, json.Unmarshal([]byte(saved), game))
+		assert.Equal(
+			t,
+			"1J* | 2?* 2?* 1? 2C* 2? 3? 3? 3? 3? 3?",
+			deckString(game),
+		)
+		assert.NilError(t, game.Redo())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		cases := []struct {
+			name  string
+			saved string
+			err   error
+		}{
+			{
+				"Version",
+				`{"version":4}`,
+				domain.ErrUnsupportedSavedGame,
+			},
+			{
+				"Card",
+				`{"version":1,"invaderDeck":{"drawn":["1C"]}}`,
+				domain.ErrInvalidInvaderCard,
+			},
+			{
+				"Event",
+				`{"version":2,"events":[{"type":"shuffle"}]}`,
+				domain.ErrUnsupportedSavedGame,
+			},
+			{
+				"Replay",
+				`{"version":2,"events":[{"type":"draw","card":"3JW"}]}`,
+				domain.ErrInvalidInvaderCard,
+			},
+			{
+				"State",
+				`{"version":3,"events":[{"type":"draw","card":"1J"}],` +
+					`"state":{"invaderDeck":{"drawn":["1W*"],` +
+					`"inDeck":["1?","1?","2?","2?","2?","2?",` +
+					`"3?","3?","3?","3?","3?"]},` +
+					`"invaderCardpool":{"1":["1W"]}}}`,
+				domain.ErrUnsupportedSavedGame,
+			},
+			{
+				"Stage",
+				`{"version":1,"invaderCardpool":{"2":["1J"]}}`,
+				domain.ErrUnsupportedSavedGame,
+			},
+			{
+				"Unrevealable",
+				`{"version":1,"invaderCardpool":{"2":["2SALT"]}}`,
+				domain.ErrInvalidInvaderCard,
+			},
+		}
+
+		for _, tc := range cases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := json.Unmarshal(
+					[]byte(tc.saved),
+					&domain.InitializedGame{},
+				)
+				assert.ErrorIs(t, err, tc.err)
+			})
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package domain
+
+// InvaderDeckSetup is how to build the physical invader deck for a game.
+type InvaderDeckSetup struct {
+	// Stages are how to prepare the cards of Stage I, II, and III.
+	Stages []StageSetup
+	// Deck is the finished deck, the first card is the top of the deck.
+	Deck []InvaderCardInDeck
+}
+
+// StageSetup is how to prepare the cards of a single stage.
+type StageSetup struct {
+	Stage int
+	// SetAside are the known cards which adversaries place in the deck.
+	SetAside []InvaderCard
+	// Removed are the known cards which aren't used this game.
+	Removed []InvaderCard
+	// Shuffled is how many of the remaining cards are shuffled
+	// and Kept is how many of them are used, the rest go back unseen.
+	Shuffled int
+	Kept     int
+}
+
+// NewInvaderDeckSetup works out how to build the invader deck of the game
+// from the deck and cardpool the adversaries set up.
+func NewInvaderDeckSetup(game *Game) InvaderDeckSetup {
+	deck := NewInvaderDeck(game)
+	icp := NewInvaderCardpool(game)
+
+	placed := make(map[InvaderCard]bool)
+	for _, c := range deck.InDeck {
+		if c.Terrain != UnknownTerrain {
+			placed[c.InvaderCard] = true
+		}
+	}
+
+	setup := InvaderDeckSetup{Deck: deck.InDeck}
+	for stg, cards := range [][]InvaderCard{
+		StageOneInvaderCards,
+		StageTwoInvaderCards,
+		StageThreeInvaderCards,
+	} {
+		ss := StageSetup{
+			Stage:    stg + 1,
+			SetAside: []InvaderCard{},
+			Removed:  []InvaderCard{},
+		}
+		for _, c := range cards {
+			switch {
+			case placed[c]:
+				ss.SetAside = append(ss.SetAside, c)
+			case icp.Revealed[ss.Stage].Contains(c):
+				ss.Removed = append(ss.Removed, c)
+			default:
+				ss.Shuffled++
+			}
+		}
+		// Special cards (e.g. Salt Deposits) come with the adversary.
+		for _, c := range deck.InDeck {
+			if c.Stage == ss.Stage && c.Terrain != UnknownTerrain &&
+				!isStandard(c.InvaderCard) {
+				ss.SetAside = append(ss.SetAside, c.InvaderCard)
+			}
+			if c.Stage == ss.Stage && c.Terrain == UnknownTerrain {
+				ss.Kept++
+			}
+		}
+
+		setup.Stages = append(setup.Stages, ss)
+	}
+
+	return setup
+}
+
+// isStandard is true for the cards in every invader deck.
+func isStandard(card InvaderCard) bool {
+	return isPhysical(card) && card != StageTwoSaltDeposits
+}
@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestNewInvaderDeckSetup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Standard", func(t *testing.T) {
+		t.Parallel()
+
+		setup := domain.NewInvaderDeckSetup(&domain.Game{})
+		assert.DeepEqual(t, []domain.StageSetup{
+			{1, []domain.InvaderCard{}, []domain.InvaderCard{}, 4, 3},
+			{2, []domain.InvaderCard{}, []domain.InvaderCard{}, 5, 4},
+			{3, []domain.InvaderCard{}, []domain.InvaderCard{}, 6, 5},
+		}, setup.Stages)
+		assert.Equal(t, 12, len(setup.Deck))
+	})
+
+	t.Run("Combined", func(t *testing.T) {
+		t.Parallel()
+
+		setup := domain.NewInvaderDeckSetup(&domain.Game{
+			LeadingAdversary:         domain.Scotland,
+			LeadingAdversaryLevel:    4,
+			SupportingAdversary:      domain.HabsburgMines,
+			SupportingAdversaryLevel: 4,
+		})
+		assert.DeepEqual(t, domain.StageSetup{
+			2,
+			[]domain.InvaderCard{
+				domain.StageTwoCoastal,
+				domain.StageTwoSaltDeposits,
+			},
+			[]domain.InvaderCard{},
+			4,
+			2,
+		}, setup.Stages[1])
+		assert.Equal(t, 2, setup.Stages[0].Kept)
+	})
+
+	t.Run("Removed", func(t *testing.T) {
+		t.Parallel()
+
+		setup := domain.NewInvaderDeckSetup(&domain.Game{
+			LeadingAdversary:      domain.HabsburgMines,
+			LeadingAdversaryLevel: 4,
+		})
+		assert.DeepEqual(t, domain.StageSetup{
+			2,
+			[]domain.InvaderCard{domain.StageTwoSaltDeposits},
+			[]domain.InvaderCard{domain.StageTwoCoastal},
+			4,
+			3,
+		}, setup.Stages[1])
+	})
+}
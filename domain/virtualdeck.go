@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// ErrNotVirtual occurs when the next card is dealt for a physical invader deck.
+var ErrNotVirtual = errors.New("the invader deck is not virtual")
+
+// DealInvaderDeck picks a physical card for each unknown card of the deck.
+// The physical cards of each stage are shuffled and dealt in order,
+// skipping the cards that are revealed or already placed in the deck
+// (e.g. Coastal Lands for Scotland or Salt Deposits for Habsburg).
+// Unknown cards are left in the deck when there aren't enough physical cards.
+func DealInvaderDeck(
+	deck InvaderDeck,
+	icp InvaderCardpool,
+	rng *rand.Rand,
+) []InvaderCard {
+	known := make(map[InvaderCard]bool)
+	for _, c := range deck.Drawn {
+		known[c.InvaderCard] = true
+	}
+	for _, c := range deck.InDeck {
+		known[c.InvaderCard] = true
+	}
+
+	// The stages are shuffled in order so the same rng deals the same cards.
+	unseen := make(map[int][]InvaderCard, 3)
+	for stg, cards := range [][]InvaderCard{
+		StageOneInvaderCards,
+		StageTwoInvaderCards,
+		StageThreeInvaderCards,
+	} {
+		stage := stg + 1
+		shuffled := make([]InvaderCard, len(cards))
+		copy(shuffled, cards)
+		rng.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+
+		for _, c := range shuffled {
+			if revealed, ok := icp.Revealed[stage]; known[c] ||
+				(ok && revealed.Contains(c)) {
+				continue
+			}
+			unseen[stage] = append(unseen[stage], c)
+		}
+	}
+
+	dealt := make([]InvaderCard, 0, len(deck.InDeck))
+	for _, c := range deck.InDeck {
+		card := c.InvaderCard
+		if card.Terrain == UnknownTerrain && len(unseen[card.Stage]) != 0 {
+			card = unseen[card.Stage][0]
+			unseen[card.Stage] = unseen[card.Stage][1:]
+		}
+		dealt = append(dealt, card)
+	}
+
+	return dealt
+}
+
+// SeedVirtual gives a virtual game without a seed a random one,
+// otherwise every unseeded virtual game would deal the same cards.
+func (g *Game) SeedVirtual() {
+	if g.Virtual && g.Seed == 0 {
+		//nolint:gosec // the deal doesn't need to be secure
+		g.Seed = rand.Int63()
+	}
+}
+
+// DrawNext deals and draws the next card of a virtual invader deck.
+// The physical cards are shuffled using the seed of the game
+// so the same game always deals the same cards.
+func (g *InitializedGame) DrawNext() (InvaderCard, error) {
+	if !g.Virtual {
+		return InvaderCard{}, ErrNotVirtual
+	}
+	if len(g.invaderdeck.InDeck) == 0 {
+		return InvaderCard{}, ErrNoInvaderCard
+	}
+
+	//nolint:gosec // the deal doesn't need to be secure
+	rng := rand.New(rand.NewSource(g.Seed))
+	card := DealInvaderDeck(*g.invaderdeck, *g.invadercardpool, rng)[0]
+
+	return card, g.Draw(card)
+}
@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestInitializedGame_DrawNext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NotVirtual", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := initGame(t, &domain.Game{}).DrawNext()
+		assert.ErrorIs(t, err, domain.ErrNotVirtual)
+	})
+
+	t.Run("WholeDeck", func(t *testing.T) {
+		t.Parallel()
+
+		// Scotland places Coastal Lands and Habsburg adds Salt Deposits.
+		game := initGame(t, &domain.Game{
+			LeadingAdversary:         domain.Scotland,
+			LeadingAdversaryLevel:    4,
+			SupportingAdversary:      domain.HabsburgMines,
+			SupportingAdversaryLevel: 4,
+			Seed:                     5,
+			Virtual:                  true,
+		})
+		indeck := game.InvaderDeck().InDeck
+
+		seen := make(map[domain.InvaderCard]bool)
+		for _, c := range indeck {
+			drawn, err := game.DrawNext()
+			assert.NilError(t, err)
+			assert.Equal(t, c.Stage, drawn.Stage)
+			assert.Assert(t, drawn.Terrain != domain.UnknownTerrain)
+			if c.Terrain != domain.UnknownTerrain {
+				assert.Equal(t, c.InvaderCard, drawn)
+			}
+			assert.Assert(t, !seen[drawn], "%s was dealt twice", drawn)
+			seen[drawn] = true
+		}
+
+		_, err := game.DrawNext()
+		assert.ErrorIs(t, err, domain.ErrNoInvaderCard)
+	})
+
+	t.Run("Seeded", func(t *testing.T) {
+		t.Parallel()
+
+		deal := func(seed int64) []domain.InvaderCard {
+			game := initGame(t, &domain.Game{Seed: seed, Virtual: true})
+			dealt := []domain.InvaderCard{}
+			for i := 0; i < 6; i++ {
+				c, err := game.DrawNext()
+				assert.NilError(t, err)
+				dealt = append(dealt, c)
+
+				// Undoing and dealing again deals the same card.
+				assert.NilError(t, game.Undo())
+				again, err := game.DrawNext()
+				assert.NilError(t, err)
+				assert.Equal(t, c, again)
+			}
+
+			return dealt
+		}
+
+		assert.DeepEqual(t, deal(9), deal(9))
+		assert.Assert(t, !cmpCards(deal(9), deal(10)))
+	})
+
+	t.Run("Unseeded", func(t *testing.T) {
+		t.Parallel()
+
+		physical := initGame(t, &domain.Game{})
+		assert.Equal(t, int64(0), physical.Seed)
+
+		first := initGame(t, &domain.Game{Virtual: true})
+		second := initGame(t, &domain.Game{Virtual: true})
+		assert.Assert(t, first.Seed != 0)
+		assert.Assert(t, first.Seed != second.Seed)
+	})
+}
+
+func cmpCards(a []domain.InvaderCard, b []domain.InvaderCard) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
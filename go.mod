@@ -6,4 +6,11 @@ require gotest.tools/v3 v3.5.0
 
 require github.com/deckarep/golang-set/v2 v2.3.0
 
-require github.com/google/go-cmp v0.5.9
+require github.com/pelletier/go-toml/v2 v2.1.0
+
+require (
+	github.com/google/go-cmp v0.5.9
+	golang.org/x/term v0.10.0
+)
+
+require golang.org/x/sys v0.10.0 // indirect
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrStreamingUnsupported occurs when the connection can't stream events.
+var ErrStreamingUnsupported = errors.New("streaming is not supported")
+
+// subscriberBuffer is how many updates a subscriber can fall behind by.
+// Subscribers that fall further behind are disconnected and can reconnect.
+const subscriberBuffer = 16
+
+// stream sends an update as a Server-Sent Event every time the game changes.
+// The first event is the current state of the game.
+func (sess *session) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, ErrStreamingUnsupported)
+
+		return
+	}
+
+	updates := make(chan UpdateResponse, subscriberBuffer)
+	sess.mu.Lock()
+	if sess.subscribers == nil {
+		sess.mu.Unlock()
+		writeError(w, http.StatusNotFound, ErrGameNotFound)
+
+		return
+	}
+	sess.subscribers[updates] = struct{}{}
+	current := sess.update("state")
+	sess.mu.Unlock()
+	defer sess.unsubscribe(updates)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := writeEvent(w, current); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case u, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, u); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, u UpdateResponse) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", u.Action, data)
+
+	return err
+}
+
+// publish sends the update to every subscriber, the lock must be held.
+func (sess *session) publish(action string) {
+	u := sess.update(action)
+	for sub := range sess.subscribers {
+		select {
+		case sub <- u:
+		default:
+			delete(sess.subscribers, sub)
+			close(sub)
+		}
+	}
+}
+
+func (sess *session) unsubscribe(sub chan UpdateResponse) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if _, ok := sess.subscribers[sub]; ok {
+		delete(sess.subscribers, sub)
+		close(sub)
+	}
+}
+
+// close disconnects every subscriber once the game has ended.
+func (sess *session) close() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	for sub := range sess.subscribers {
+		close(sub)
+	}
+	sess.subscribers = nil
+}
+
+// update is the current state of the game, the lock must be held.
+func (sess *session) update(action string) UpdateResponse {
+	u := UpdateResponse{
+		Action: action,
+		Game:   sess.response(),
+	}
+
+	deck := sess.game.InvaderDeck()
+	if len(deck.InDeck) == 0 {
+		return u
+	}
+
+	stage := deck.InDeck[0].Stage
+	pcts, err := sess.game.PredictStage(stage)
+	if err == nil {
+		u.Prediction = &PredictionResponse{stage, pcts}
+	}
+
+	return u
+}
@@ -0,0 +1,102 @@
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brycekbargar/spise/server"
+	"gotest.tools/v3/assert"
+)
+
+// readEvent reads the next Server-Sent Event from the stream.
+func readEvent(
+	t *testing.T,
+	events *bufio.Scanner,
+) (string, server.UpdateResponse) {
+	t.Helper()
+
+	var name string
+	var update server.UpdateResponse
+	for events.Scan() {
+		line := events.Text()
+		switch {
+		case line == "":
+			return name, update
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data := strings.TrimPrefix(line, "data: ")
+			assert.NilError(t, json.Unmarshal([]byte(data), &update))
+		}
+	}
+	assert.NilError(t, events.Err())
+	t.Fatal("the stream ended")
+
+	return name, update
+}
+
+func TestServer_Events(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New())
+	t.Cleanup(srv.Close)
+
+	_, game := do(t, srv, http.MethodPost, "/games", `{}`)
+	id, _ := game["id"].(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		srv.URL+"/games/"+id+"/events",
+		nil,
+	)
+	assert.NilError(t, err)
+	res, err := srv.Client().Do(req)
+	assert.NilError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	events := bufio.NewScanner(res.Body)
+
+	name, update := readEvent(t, events)
+	assert.Equal(t, "state", name)
+	assert.Equal(t, id, update.Game.ID)
+	assert.Equal(t, 0, len(update.Game.Drawn))
+	assert.Equal(t, 4, len(update.Prediction.Terrains))
+
+	code, _ := do(t, srv, http.MethodPost, "/games/"+id+"/draw", `{
+		"card": "1J"
+	}`)
+	assert.Equal(t, http.StatusOK, code)
+	name, update = readEvent(t, events)
+	assert.Equal(t, "draw", name)
+	assert.Equal(t, "draw", update.Action)
+	assert.Equal(t, "1J*", update.Game.Drawn[0].String())
+	assert.Equal(t, 1, update.Prediction.Stage)
+	assert.Equal(t, 3, len(update.Prediction.Terrains))
+
+	// Failed actions don't change the game so there's no update.
+	code, _ = do(t, srv, http.MethodPost, "/games/"+id+"/draw", `{
+		"card": "1J"
+	}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+
+	code, _ = do(t, srv, http.MethodPost, "/games/"+id+"/undo", "")
+	assert.Equal(t, http.StatusOK, code)
+	name, update = readEvent(t, events)
+	assert.Equal(t, "undo", name)
+	assert.Equal(t, 0, len(update.Game.Drawn))
+
+	// Ending the game ends the stream.
+	code, _ = do(t, srv, http.MethodDelete, "/games/"+id, "")
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Assert(t, !events.Scan())
+}
@@ -0,0 +1,488 @@
+// Package server hosts games behind a JSON http api.
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+var (
+	// ErrNotFound occurs when a request isn't for any known resource.
+	ErrNotFound = errors.New("not found")
+	// ErrGameNotFound occurs when a request is for a game that doesn't exist.
+	ErrGameNotFound = errors.New("game not found")
+	// ErrBadRequest occurs when a request can't be understood.
+	ErrBadRequest = errors.New("bad request")
+)
+
+// Server hosts any number of games.
+type Server struct {
+	mu    sync.RWMutex
+	games map[string]*session
+}
+
+// session is a single game, only one request can change it at a time.
+type session struct {
+	mu   sync.Mutex
+	id   string
+	game *domain.InitializedGame
+	// subscribers receive every update to the game.
+	subscribers map[chan UpdateResponse]struct{}
+}
+
+// New creates a server without any games.
+func New() *Server {
+	return &Server{
+		games: make(map[string]*session),
+	}
+}
+
+// GameResponse is the state of a single game.
+type GameResponse struct {
+	ID string `json:"id"`
+	*domain.Game
+	Drawn  []domain.InvaderCardDrawn  `json:"drawn"`
+	InDeck []domain.InvaderCardInDeck `json:"inDeck"`
+	Track  domain.InvaderTrack        `json:"track"`
+	// Escalations are every Escalation triggered so far, oldest first.
+	Escalations []domain.Escalation `json:"escalations"`
+}
+
+// PredictionResponse is the prediction for the next card of a stage.
+type PredictionResponse struct {
+	Stage    int                        `json:"stage"`
+	Terrains map[domain.Terrain]float64 `json:"terrains"`
+}
+
+// UpdateResponse is sent to subscribers every time the game changes.
+type UpdateResponse struct {
+	// Action is what changed the game (e.g. draw or undo).
+	Action string       `json:"action"`
+	Game   GameResponse `json:"game"`
+	// Prediction is for the next card, it is nil once the deck is empty.
+	Prediction *PredictionResponse `json:"prediction"`
+}
+
+// CardRequest is the body of requests acting on a single card.
+type CardRequest struct {
+	Card domain.InvaderCard `json:"card"`
+}
+
+// ErrorResponse is the body of any failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP routes the request.
+//
+//	POST   /games                                  create a game
+//	GET    /games/{id}                             get the deck state
+//	DELETE /games/{id}                             end the game
+//	GET    /games/{id}/events                      stream updates (SSE)
+//	GET    /games/{id}/predictions[?stage=N]       predict the next card
+//	GET    /games/{id}/forecast[?turns=N]          forecast the next turns
+//	POST   /games/{id}/draw                        draw (or deal) a card
+//	POST   /games/{id}/return                      return a card
+//	POST   /games/{id}/entrench                    entrench a card
+//	POST   /games/{id}/ignore-rising-interest      apply a fear effect
+//	POST   /games/{id}/distract-hardworking-settlers
+//	POST   /games/{id}/advance                     advance the invader track
+//	POST   /games/{id}/undo
+//	POST   /games/{id}/redo
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "games" || len(parts) > 3 {
+		writeError(w, http.StatusNotFound, ErrNotFound)
+
+		return
+	}
+
+	if len(parts) == 1 {
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w, http.MethodPost)
+
+			return
+		}
+		srv.create(w, r)
+
+		return
+	}
+
+	sess, ok := srv.session(parts[1])
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrGameNotFound)
+
+		return
+	}
+
+	if len(parts) == 2 {
+		switch r.Method {
+		case http.MethodGet:
+			sess.mu.Lock()
+			defer sess.mu.Unlock()
+			writeJSON(w, http.StatusOK, sess.response())
+		case http.MethodDelete:
+			srv.mu.Lock()
+			delete(srv.games, sess.id)
+			srv.mu.Unlock()
+			sess.close()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeMethodNotAllowed(w, http.MethodGet, http.MethodDelete)
+		}
+
+		return
+	}
+
+	if parts[2] == "events" {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, http.MethodGet)
+
+			return
+		}
+		sess.stream(w, r)
+
+		return
+	}
+
+	if parts[2] == "forecast" {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, http.MethodGet)
+
+			return
+		}
+		sess.forecast(w, r)
+
+		return
+	}
+
+	if parts[2] == "predictions" {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, http.MethodGet)
+
+			return
+		}
+		sess.predict(w, r)
+
+		return
+	}
+
+	act, ok := actions[parts[2]]
+	if !ok {
+		writeError(w, http.StatusNotFound, ErrNotFound)
+
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, http.MethodPost)
+
+		return
+	}
+	sess.act(w, r, act)
+}
+
+func (srv *Server) create(w http.ResponseWriter, r *http.Request) {
+	game := &domain.Game{}
+	if err := decodeJSON(w, r, game); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = fmt.Errorf("%w: the game is missing", ErrBadRequest)
+		}
+		writeError(w, statusCode(err), err)
+
+		return
+	}
+
+	init, err := game.Init()
+	if err != nil {
+		writeError(w, statusCode(err), err)
+
+		return
+	}
+
+	id, err := newID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	sess := &session{
+		id:          id,
+		game:        init,
+		subscribers: make(map[chan UpdateResponse]struct{}),
+	}
+	srv.mu.Lock()
+	srv.games[id] = sess
+	srv.mu.Unlock()
+
+	w.Header().Set("Location", "/games/"+id)
+	writeJSON(w, http.StatusCreated, sess.response())
+}
+
+func (srv *Server) session(id string) (*session, bool) {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+
+	sess, ok := srv.games[id]
+
+	return sess, ok
+}
+
+// action changes a game, the card is only set for actions which need one.
+type action struct {
+	name string
+	card bool
+	run  func(g *domain.InitializedGame, card domain.InvaderCard) error
+}
+
+var actions = map[string]action{
+	"draw": {
+		"draw",
+		true,
+		func(g *domain.InitializedGame, card domain.InvaderCard) error {
+			// Virtual decks deal the card when there isn't one.
+			if card == (domain.InvaderCard{}) && g.Virtual {
+				_, err := g.DrawNext()
+
+				return err
+			}
+
+			return g.Draw(card)
+		},
+	},
+	"return":   {"return", true, (*domain.InitializedGame).Return},
+	"entrench": {"entrench", true, (*domain.InitializedGame).Entrenched},
+	"ignore-rising-interest": {
+		"ignore-rising-interest",
+		false,
+		func(g *domain.InitializedGame, _ domain.InvaderCard) error {
+			return g.IgnoreRisingInterest()
+		},
+	},
+	"distract-hardworking-settlers": {
+		"distract-hardworking-settlers",
+		false,
+		func(g *domain.InitializedGame, _ domain.InvaderCard) error {
+			return g.DistractHardworkingSettlers()
+		},
+	},
+	"advance": {
+		"advance",
+		false,
+		func(g *domain.InitializedGame, _ domain.InvaderCard) error {
+			return g.Advance()
+		},
+	},
+	"undo": {
+		"undo",
+		false,
+		func(g *domain.InitializedGame, _ domain.InvaderCard) error {
+			return g.Undo()
+		},
+	},
+	"redo": {
+		"redo",
+		false,
+		func(g *domain.InitializedGame, _ domain.InvaderCard) error {
+			return g.Redo()
+		},
+	},
+}
+
+func (sess *session) act(w http.ResponseWriter, r *http.Request, act action) {
+	var req CardRequest
+	if act.card {
+		err := decodeJSON(w, r, &req)
+		if err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, statusCode(err), err)
+
+			return
+		}
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if err := act.run(sess.game, req.Card); err != nil {
+		writeError(w, statusCode(err), err)
+
+		return
+	}
+
+	sess.publish(act.name)
+	writeJSON(w, http.StatusOK, sess.response())
+}
+
+// maxBodyBytes is far larger than any request body the api understands.
+const maxBodyBytes = 1 << 16
+
+// decodeJSON decodes the whole request body into v.
+// Unknown fields and anything after the first value are bad requests,
+// io.EOF is returned as is when the body is empty.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return err
+		}
+
+		return fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return fmt.Errorf(
+			"%w: unexpected data after the request body",
+			ErrBadRequest,
+		)
+	}
+
+	return nil
+}
+
+func (sess *session) predict(w http.ResponseWriter, r *http.Request) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	stage := 0
+	if s := r.URL.Query().Get("stage"); s != "" {
+		stg, err := strconv.Atoi(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf(
+				"%w: %q is not a stage",
+				ErrBadRequest,
+				s,
+			))
+
+			return
+		}
+		stage = stg
+	} else {
+		deck := sess.game.InvaderDeck()
+		if len(deck.InDeck) == 0 {
+			writeError(w, statusCode(domain.ErrNoInvaderCard),
+				domain.ErrNoInvaderCard)
+
+			return
+		}
+		stage = deck.InDeck[0].Stage
+	}
+
+	pcts, err := sess.game.PredictStage(stage)
+	if err != nil {
+		writeError(w, statusCode(err), err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PredictionResponse{stage, pcts})
+}
+
+func (sess *session) forecast(w http.ResponseWriter, r *http.Request) {
+	turns := 3
+	if t := r.URL.Query().Get("turns"); t != "" {
+		n, err := strconv.Atoi(t)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf(
+				"%w: %q is not a number of turns",
+				ErrBadRequest,
+				t,
+			))
+
+			return
+		}
+		turns = n
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	forecasts, err := sess.game.Forecast(turns)
+	if err != nil {
+		writeError(w, statusCode(err), err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, forecasts)
+}
+
+func (sess *session) response() GameResponse {
+	deck := sess.game.InvaderDeck()
+
+	return GameResponse{
+		ID:     sess.id,
+		Game:   sess.game.Game,
+		Drawn:  deck.Drawn,
+		InDeck: deck.InDeck,
+		Track:  sess.game.InvaderTrack(),
+
+		Escalations: sess.game.Escalations(),
+	}
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// statusCode maps domain errors to http status codes.
+func statusCode(err error) int {
+	var syntax *json.SyntaxError
+	var typ *json.UnmarshalTypeError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrInvalidInvaderCard),
+		errors.Is(err, domain.ErrNotVirtual),
+		errors.Is(err, domain.ErrInvaderCardNotReturnable),
+		errors.Is(err, domain.ErrNotEntrenched),
+		errors.Is(err, domain.ErrNoInvaderCard),
+		errors.Is(err, domain.ErrUnknownPredictor),
+		errors.Is(err, domain.ErrInvalidGame):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrNothingToUndo),
+		errors.Is(err, domain.ErrNothingToRedo):
+		return http.StatusConflict
+	case errors.Is(err, ErrBadRequest),
+		errors.As(err, &syntax),
+		errors.As(err, &typ):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint:errchkjson // the client is gone if this fails
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{err.Error()})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(
+		w,
+		http.StatusMethodNotAllowed,
+		fmt.Errorf("%w: method not allowed", ErrBadRequest),
+	)
+}
@@ -0,0 +1,366 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"github.com/brycekbargar/spise/server"
+	"gotest.tools/v3/assert"
+)
+
+func do(
+	t *testing.T,
+	srv *httptest.Server,
+	method string,
+	path string,
+	body string,
+) (int, map[string]any) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	assert.NilError(t, err)
+	res, err := srv.Client().Do(req)
+	assert.NilError(t, err)
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNoContent {
+		return res.StatusCode, nil
+	}
+
+	parsed := make(map[string]any)
+	assert.NilError(t, json.NewDecoder(res.Body).Decode(&parsed))
+
+	return res.StatusCode, parsed
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New())
+	t.Cleanup(srv.Close)
+
+	code, game := do(t, srv, http.MethodPost, "/games", `{
+		"leadingAdversary": "russia",
+		"leadingAdversaryLevel": 5,
+		"supportingAdversary": "scotland",
+		"supportingAdversaryLevel": 2
+	}`)
+	assert.Equal(t, http.StatusCreated, code, game)
+	id, _ := game["id"].(string)
+	assert.Assert(t, id != "")
+	assert.Equal(t, "russia", game["leadingAdversary"])
+	assert.Equal(t, 12, len(game["inDeck"].([]any)))
+
+	code, game = do(t, srv, http.MethodPost, "/games/"+id+"/draw", `{
+		"card": "1J"
+	}`)
+	assert.Equal(t, http.StatusOK, code, game)
+	assert.DeepEqual(t, []any{"1J*"}, game["drawn"])
+
+	code, game = do(t, srv, http.MethodPost, "/games/"+id+"/draw", `{
+		"card": "1J"
+	}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, code, game)
+	assert.Equal(t, "1J has already been drawn", game["error"])
+
+	code, game = do(t, srv, http.MethodPost, "/games/"+id+"/entrench", `{
+		"card": "2M"
+	}`)
+	assert.Equal(t, http.StatusOK, code, game)
+	assert.DeepEqual(t, []any{"1J*", "2M*"}, game["drawn"])
+	// Entrenched cards aren't drawn so they don't escalate.
+	assert.DeepEqual(t, []any{}, game["escalations"])
+
+	code, game = do(t, srv, http.MethodPost, "/games/"+id+"/undo", "")
+	assert.Equal(t, http.StatusOK, code, game)
+	assert.DeepEqual(t, []any{"1J*"}, game["drawn"])
+
+	code, pred := do(t, srv, http.MethodGet, "/games/"+id+"/predictions", "")
+	assert.Equal(t, http.StatusOK, code, pred)
+	assert.DeepEqual(t, map[string]any{
+		"stage": float64(1),
+		"terrains": map[string]any{
+			"mountain": 1.0 / 3,
+			"sands":    1.0 / 3,
+			"wetland":  1.0 / 3,
+		},
+	}, pred)
+
+	code, pred = do(
+		t,
+		srv,
+		http.MethodGet,
+		"/games/"+id+"/predictions?stage=4",
+		"",
+	)
+	assert.Equal(t, http.StatusUnprocessableEntity, code, pred)
+
+	res, err := srv.Client().Get(srv.URL + "/games/" + id + "/forecast?turns=2")
+	assert.NilError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var forecasts []domain.Forecast
+	assert.NilError(t, json.NewDecoder(res.Body).Decode(&forecasts))
+	assert.Equal(t, 2, len(forecasts))
+	assert.DeepEqual(
+		t,
+		map[domain.Terrain]float64{domain.Jungle: 1},
+		forecasts[0].Build,
+	)
+
+	code, game = do(t, srv, http.MethodGet, "/games/"+id, "")
+	assert.Equal(t, http.StatusOK, code, game)
+	assert.DeepEqual(t, []any{"1J*"}, game["drawn"])
+
+	code, _ = do(t, srv, http.MethodDelete, "/games/"+id, "")
+	assert.Equal(t, http.StatusNoContent, code)
+	code, _ = do(t, srv, http.MethodGet, "/games/"+id, "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New())
+	t.Cleanup(srv.Close)
+
+	_, game := do(t, srv, http.MethodPost, "/games", `{}`)
+	id, _ := game["id"].(string)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{"Root", http.MethodGet, "/", "", http.StatusNotFound},
+		{"ListGames", http.MethodGet, "/games", "", http.StatusMethodNotAllowed},
+		{"NoBody", http.MethodPost, "/games", "", http.StatusBadRequest},
+		{"BadGame", http.MethodPost, "/games", "{", http.StatusBadRequest},
+		{
+			"InvalidGame",
+			http.MethodPost,
+			"/games",
+			`{"leadingAdversary": "atlantis"}`,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"UnknownField",
+			http.MethodPost,
+			"/games",
+			`{"leadingAdversay": "england"}`,
+			http.StatusBadRequest,
+		},
+		{
+			"TrailingData",
+			http.MethodPost,
+			"/games",
+			`{} {}`,
+			http.StatusBadRequest,
+		},
+		{
+			"LargeGame",
+			http.MethodPost,
+			"/games",
+			`{"seed": 1` + strings.Repeat(" ", 1<<16) + `}`,
+			http.StatusRequestEntityTooLarge,
+		},
+		{"NoGame", http.MethodGet, "/games/nope", "", http.StatusNotFound},
+		{
+			"NoAction",
+			http.MethodPost,
+			"/games/" + id + "/shuffle",
+			"",
+			http.StatusNotFound,
+		},
+		{
+			"GetAction",
+			http.MethodGet,
+			"/games/" + id + "/draw",
+			"",
+			http.StatusMethodNotAllowed,
+		},
+		{
+			"BadCard",
+			http.MethodPost,
+			"/games/" + id + "/draw",
+			`{"card": "1X"}`,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"BadBody",
+			http.MethodPost,
+			"/games/" + id + "/draw",
+			`1J`,
+			http.StatusBadRequest,
+		},
+		{
+			"UnknownCardField",
+			http.MethodPost,
+			"/games/" + id + "/draw",
+			`{"card": "1J", "cards": ["1W"]}`,
+			http.StatusBadRequest,
+		},
+		{
+			"TrailingCard",
+			http.MethodPost,
+			"/games/" + id + "/draw",
+			`{"card": "1J"} {"card": "1W"}`,
+			http.StatusBadRequest,
+		},
+		{
+			"NotEntrenched",
+			http.MethodPost,
+			"/games/" + id + "/entrench",
+			`{"card": "2J"}`,
+			http.StatusUnprocessableEntity,
+		},
+		{
+			"NothingToRedo",
+			http.MethodPost,
+			"/games/" + id + "/redo",
+			"",
+			http.StatusConflict,
+		},
+		{
+			"BadStage",
+			http.MethodGet,
+			"/games/" + id + "/predictions?stage=II",
+			"",
+			http.StatusBadRequest,
+		},
+		{
+			"BadTurns",
+			http.MethodGet,
+			"/games/" + id + "/forecast?turns=0",
+			"",
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, body := do(t, srv, tc.method, tc.path, tc.body)
+			assert.Equal(t, tc.code, code, body)
+			assert.Assert(t, body["error"] != "")
+		})
+	}
+}
+
+func TestServer_Virtual(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New())
+	t.Cleanup(srv.Close)
+
+	_, game := do(t, srv, http.MethodPost, "/games", `{
+		"seed": 4,
+		"virtual": true
+	}`)
+	id, _ := game["id"].(string)
+
+	// The card is dealt when it isn't given.
+	code, game := do(t, srv, http.MethodPost, "/games/"+id+"/draw", "")
+	assert.Equal(t, http.StatusOK, code, game)
+	drawn, _ := game["drawn"].([]any)
+	assert.Equal(t, 1, len(drawn))
+	assert.Assert(t, drawn[0] != "1?*")
+
+	code, game = do(t, srv, http.MethodPost, "/games/"+id+"/draw", "")
+	assert.Equal(t, http.StatusOK, code, game)
+	assert.Equal(t, 2, len(game["drawn"].([]any)))
+}
+
+func TestServer_VirtualUnseeded(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New())
+	t.Cleanup(srv.Close)
+
+	deal := func() (any, []any) {
+		t.Helper()
+
+		code, game := do(t, srv, http.MethodPost, "/games", `{
+			"virtual": true
+		}`)
+		assert.Equal(t, http.StatusCreated, code, game)
+		assert.Assert(t, game["seed"] != nil)
+		id, _ := game["id"].(string)
+		for inDeck, _ := game["inDeck"].([]any); len(inDeck) > 0; {
+			code, game = do(t, srv, http.MethodPost, "/games/"+id+"/draw", "")
+			inDeck, _ = game["inDeck"].([]any)
+			assert.Equal(t, http.StatusOK, code, game)
+		}
+		drawn, _ := game["drawn"].([]any)
+
+		return game["seed"], drawn
+	}
+
+	// Any two games could deal the same cards, but not every game.
+	seed, drawn := deal()
+	for i := 0; i < 10; i++ {
+		s, d := deal()
+		assert.Assert(t, s != seed)
+		if !reflect.DeepEqual(drawn, d) {
+			return
+		}
+	}
+	t.Fatalf("every unseeded game dealt %v", drawn)
+}
+
+func TestServer_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(server.New())
+	t.Cleanup(srv.Close)
+
+	_, game := do(t, srv, http.MethodPost, "/games", `{}`)
+	id, _ := game["id"].(string)
+
+	// Only one of the racing draws of each card can succeed.
+	var wg sync.WaitGroup
+	codes := make(chan int, 2*len(domain.StageOneInvaderCards))
+	for _, c := range domain.StageOneInvaderCards[:3] {
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(card domain.InvaderCard) {
+				defer wg.Done()
+				res, err := srv.Client().Post(
+					srv.URL+"/games/"+id+"/draw",
+					"application/json",
+					strings.NewReader(`{"card": "`+card.String()+`"}`),
+				)
+				if err != nil {
+					codes <- 0
+
+					return
+				}
+				res.Body.Close()
+				codes <- res.StatusCode
+			}(c)
+		}
+	}
+	wg.Wait()
+	close(codes)
+
+	ok := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			ok++
+		}
+	}
+	assert.Equal(t, 3, ok)
+
+	_, game = do(t, srv, http.MethodGet, "/games/"+id, "")
+	assert.Equal(t, 3, len(game["drawn"].([]any)))
+}
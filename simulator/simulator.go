@@ -0,0 +1,187 @@
+// Package simulator plays out the invader deck of a game many times.
+package simulator
+
+import (
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/brycekbargar/spise/domain"
+)
+
+// ErrNoRuns occurs when a simulation is asked for less than one run.
+var ErrNoRuns = errors.New("at least one run must be simulated")
+
+// Options control how a simulation is run.
+type Options struct {
+	// Runs is how many games are played out.
+	Runs int
+	// Seed makes the simulation repeatable,
+	// the same seed always gives the same results.
+	Seed int64
+	// Workers is how many games are played at once,
+	// it defaults to the number of CPUs.
+	Workers int
+}
+
+// Results are the average number of times each terrain ravages,
+// builds, and explores over a single game.
+type Results struct {
+	Runs int
+	// Phases is the average number of Invader Phases.
+	Phases  float64
+	Ravage  map[domain.Terrain]float64
+	Build   map[domain.Terrain]float64
+	Explore map[domain.Terrain]float64
+}
+
+// counts are the totals of one or more runs.
+type counts struct {
+	phases  int
+	ravage  map[domain.Terrain]int
+	build   map[domain.Terrain]int
+	explore map[domain.Terrain]int
+}
+
+func newCounts() *counts {
+	return &counts{
+		ravage:  make(map[domain.Terrain]int),
+		build:   make(map[domain.Terrain]int),
+		explore: make(map[domain.Terrain]int),
+	}
+}
+
+func (c *counts) add(other *counts) {
+	c.phases += other.phases
+	for t, n := range other.ravage {
+		c.ravage[t] += n
+	}
+	for t, n := range other.build {
+		c.build[t] += n
+	}
+	for t, n := range other.explore {
+		c.explore[t] += n
+	}
+}
+
+// Run plays out the invader deck of the game.
+// Each run builds the deck for the adversaries, resolves the unknown cards
+// at random from the physical cards, and advances every card along
+// the invader track until the deck runs out.
+// Fear cards and events which change the deck aren't simulated.
+func Run(game *domain.Game, opts Options) (Results, error) {
+	if opts.Runs < 1 {
+		return Results{}, ErrNoRuns
+	}
+	if err := game.Validate(); err != nil {
+		return Results{}, err
+	}
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > opts.Runs {
+		workers = opts.Runs
+	}
+
+	// Each run has its own seed so the results don't depend on the workers.
+	runs := make(chan int64)
+	go func() {
+		defer close(runs)
+		//nolint:gosec // the simulation doesn't need to be secure
+		seeds := rand.New(rand.NewSource(opts.Seed))
+		for r := 0; r < opts.Runs; r++ {
+			runs <- seeds.Int63()
+		}
+	}()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	total := newCounts()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			worker := newCounts()
+			for seed := range runs {
+				//nolint:gosec // the simulation doesn't need to be secure
+				play(game, rand.New(rand.NewSource(seed)), worker)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			total.add(worker)
+		}()
+	}
+	wg.Wait()
+
+	avg := func(totals map[domain.Terrain]int) map[domain.Terrain]float64 {
+		avgs := make(map[domain.Terrain]float64, len(totals))
+		for t, n := range totals {
+			avgs[t] = float64(n) / float64(opts.Runs)
+		}
+
+		return avgs
+	}
+
+	return Results{
+		Runs:    opts.Runs,
+		Phases:  float64(total.phases) / float64(opts.Runs),
+		Ravage:  avg(total.ravage),
+		Build:   avg(total.build),
+		Explore: avg(total.explore),
+	}, nil
+}
+
+// play plays out a single game.
+// The last Invader Phase ravages and builds but can't explore
+// since the invaders win when there isn't a card to draw.
+func play(game *domain.Game, rng *rand.Rand, c *counts) {
+	deck := resolve(game, rng)
+	track := domain.NewInvaderTrack(game)
+
+	count := func(totals map[domain.Terrain]int, cards []domain.InvaderCard) {
+		for _, card := range cards {
+			totals[card.Terrain]++
+			if card.Terrain2 != domain.UnknownTerrain {
+				totals[card.Terrain2]++
+			}
+		}
+	}
+
+	for phase := 0; phase <= len(deck); phase++ {
+		c.phases++
+		count(c.ravage, track.Ravage)
+		count(c.build, track.Builds())
+		if phase == len(deck) {
+			break
+		}
+
+		track.Explore = []domain.InvaderCard{deck[phase]}
+		count(c.explore, track.Explore)
+		track.Advance()
+	}
+}
+
+// resolve builds the invader deck of the game
+// and replaces the unknown cards with shuffled physical cards.
+func resolve(game *domain.Game, rng *rand.Rand) []domain.InvaderCard {
+	dealt := domain.DealInvaderDeck(
+		*domain.NewInvaderDeck(game),
+		*domain.NewInvaderCardpool(game),
+		rng,
+	)
+
+	deck := make([]domain.InvaderCard, 0, len(dealt))
+	for _, c := range dealt {
+		// There are more unknown cards than physical ones left.
+		if c.Terrain == domain.UnknownTerrain {
+			continue
+		}
+		deck = append(deck, c)
+	}
+
+	return deck
+}
@@ -0,0 +1,115 @@
+package simulator_test
+
+import (
+	"testing"
+
+	"github.com/brycekbargar/spise/domain"
+	"github.com/brycekbargar/spise/simulator"
+	"gotest.tools/v3/assert"
+)
+
+//nolint:exhaustruct
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NoRuns", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := simulator.Run(&domain.Game{}, simulator.Options{})
+		assert.ErrorIs(t, err, simulator.ErrNoRuns)
+	})
+
+	t.Run("InvalidGame", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := simulator.Run(&domain.Game{
+			LeadingAdversary:      domain.England,
+			LeadingAdversaryLevel: 7,
+		}, simulator.Options{Runs: 1})
+		assert.ErrorIs(t, err, domain.ErrInvalidGame)
+	})
+
+	t.Run("Totals", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := simulator.Run(&domain.Game{}, simulator.Options{
+			Runs: 100,
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, 100, res.Runs)
+		// 12 cards are explored and the invaders win on the 13th phase.
+		assert.Equal(t, 13.0, res.Phases)
+
+		// Stage III cards have two terrains.
+		explored := 0.0
+		for _, n := range res.Explore {
+			explored += n
+		}
+		assert.Equal(t, 3.0+4.0+5.0*2, explored)
+
+		// Cards build after they explore and ravage after they build.
+		assert.Assert(t, res.Build[domain.Jungle] <= res.Explore[domain.Jungle])
+		assert.Assert(t, res.Ravage[domain.Jungle] <= res.Build[domain.Jungle])
+	})
+
+	t.Run("Seeded", func(t *testing.T) {
+		t.Parallel()
+
+		game := &domain.Game{
+			LeadingAdversary:         domain.Russia,
+			LeadingAdversaryLevel:    5,
+			SupportingAdversary:      domain.Scotland,
+			SupportingAdversaryLevel: 2,
+		}
+		serial, err := simulator.Run(game, simulator.Options{
+			Runs:    500,
+			Seed:    11,
+			Workers: 1,
+		})
+		assert.NilError(t, err)
+		parallel, err := simulator.Run(game, simulator.Options{
+			Runs:    500,
+			Seed:    11,
+			Workers: 8,
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, serial, parallel)
+
+		// Neighbouring seeds don't share any of their runs.
+		first, err := simulator.Run(game, simulator.Options{Runs: 1, Seed: 11})
+		assert.NilError(t, err)
+		next, err := simulator.Run(game, simulator.Options{Runs: 1, Seed: 12})
+		assert.NilError(t, err)
+		both, err := simulator.Run(game, simulator.Options{Runs: 2, Seed: 11})
+		assert.NilError(t, err)
+		shared := true
+		for _, trn := range domain.AllTerrains {
+			for _, totals := range [][3]map[domain.Terrain]float64{
+				{both.Ravage, first.Ravage, next.Ravage},
+				{both.Build, first.Build, next.Build},
+				{both.Explore, first.Explore, next.Explore},
+			} {
+				shared = shared &&
+					2*totals[0][trn] == totals[1][trn]+totals[2][trn]
+			}
+		}
+		assert.Assert(t, !shared)
+	})
+
+	t.Run("HighImmigration", func(t *testing.T) {
+		t.Parallel()
+
+		// England builds each card a second time from High Immigration,
+		// except for the last two Stage III cards which run out of time.
+		res, err := simulator.Run(&domain.Game{
+			LeadingAdversary:      domain.England,
+			LeadingAdversaryLevel: 4,
+		}, simulator.Options{Runs: 100})
+		assert.NilError(t, err)
+		built := 0.0
+		for _, n := range res.Build {
+			built += n
+		}
+		assert.Equal(t, 2*(3.0+4.0+5.0*2)-2*2, built)
+	})
+}